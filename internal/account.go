@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/rs/zerolog/log"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+const (
+	// accountsOrganization selects every active account of the organization.
+	accountsOrganization = "organization"
+	// accountsFilePrefix selects the accounts listed in a file, one account ID per line.
+	accountsFilePrefix = "file://"
+	// defaultAccountRole is the role created by AWS Organizations in member accounts.
+	defaultAccountRole = "OrganizationAccountAccessRole"
+)
+
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// accountAPI queries the services in one of the accounts searched in multi-account mode.
+type accountAPI struct {
+	account   svc.Account
+	awsCfg    aws.Config
+	partition svc.Partition
+	// apis are keyed by service name.
+	apis map[string]svc.AwsresqAPI
+
+	aliasOnce sync.Once
+	alias     string
+}
+
+// buildAccounts resolves the accounts option into the accounts to search.
+// The option is either "organization", a comma separated list of account IDs, or "file://" followed by a file path.
+func buildAccounts(ctx context.Context, cfg aws.Config, partition svc.Partition, accounts string) ([]svc.Account, error) {
+	var ids []string
+	switch {
+	case accounts == accountsOrganization:
+		return svc.ListOrganizationAccounts(ctx, cfg, partition)
+	case strings.HasPrefix(accounts, accountsFilePrefix):
+		lines, err := readAccountsFile(strings.TrimPrefix(accounts, accountsFilePrefix))
+		if err != nil {
+			return nil, err
+		}
+		ids = lines
+	default:
+		ids = strings.Split(accounts, ",")
+	}
+
+	result := make([]svc.Account, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if !accountIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid account id: '%s'", id)
+		}
+		result = append(result, svc.Account{ID: id})
+	}
+	return result, nil
+}
+
+// readAccountsFile reads account IDs from path, ignoring blank lines and lines starting with '#'.
+func readAccountsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// searchAccounts queries resource in every account and merges the results annotated with the account.
+// Accounts are queried concurrently, and their region queries share the limiter of the query option,
+// which bounds the regions queried in all accounts together.
+func searchAccounts(ctx context.Context, accounts []*accountAPI, service, resource string) (*svc.ResultList, error) {
+	resultLists := make([]*svc.ResultList, len(accounts))
+	errs := make([]error, len(accounts))
+	var wg sync.WaitGroup
+	for i := range accounts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resultLists[i], errs[i] = accounts[i].query(ctx, service, resource)
+		}(i)
+	}
+	wg.Wait()
+
+	merged := &svc.ResultList{
+		Service:  service,
+		Resource: resource,
+		Results:  []interface{}{},
+	}
+	failed := 0
+	for i, resultList := range resultLists {
+		if resultList == nil {
+			failed++
+			merged.Errors = append(merged.Errors, svc.QueryError{
+				Account: accounts[i].account.ID,
+				Message: errs[i].Error(),
+			})
+			continue
+		}
+		if errs[i] != nil {
+			failed++
+		}
+		merged.Results = append(merged.Results, resultList.Results...)
+		merged.Errors = append(merged.Errors, resultList.Errors...)
+	}
+
+	if failed == len(accounts) {
+		return merged, svc.ErrQueryFailed
+	}
+	return merged, nil
+}
+
+// query queries resource of service in the account and annotates the results with the account ID and alias.
+func (a *accountAPI) query(ctx context.Context, service, resource string) (*svc.ResultList, error) {
+	resultList, queryErr := a.apis[service].QueryContext(ctx, resource)
+	if resultList == nil {
+		return nil, queryErr
+	}
+	if err := annotateAccount(resultList, a.account.ID, a.lookupAlias(ctx)); err != nil {
+		return nil, err
+	}
+	return resultList, queryErr
+}
+
+// lookupAlias returns the alias of the account, which is looked up once for all the services searched.
+func (a *accountAPI) lookupAlias(ctx context.Context) string {
+	a.aliasOnce.Do(func() {
+		alias, err := svc.LookupAccountAlias(ctx, a.awsCfg, a.partition)
+		if err != nil {
+			log.Warn().Err(err).Msgf("failed to lookup alias of account %s", a.account.ID)
+		}
+		// fall back to the name registered in the organization when the account has no alias
+		if alias == "" {
+			alias = a.account.Name
+		}
+		a.alias = alias
+	})
+	return a.alias
+}
+
+// annotateAccount adds the account and its alias to every result and the account to every error.
+// Envelopes have their own fields for them. Raw results are converted into generic objects
+// so that AccountId and AccountAlias appear next to the fields of the resource.
+func annotateAccount(resultList *svc.ResultList, accountID, alias string) error {
+	for i, result := range resultList.Results {
+		if envelope, ok := result.(svc.Envelope); ok {
+			envelope.Account = accountID
+			envelope.AccountAlias = alias
+			resultList.Results[i] = envelope
+			continue
+		}
+		generic, err := toGeneric(result)
+		if err != nil {
+			return err
+		}
+		object, ok := generic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		object["AccountId"] = accountID
+		object["AccountAlias"] = alias
+		resultList.Results[i] = object
+	}
+	for i := range resultList.Errors {
+		resultList.Errors[i].Account = accountID
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+func TestBuildAccounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.txt")
+	content := "# production\n111111111111\n\n222222222222\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		accounts  string
+		expected  []svc.Account
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "build accounts from list",
+			accounts: "111111111111,222222222222",
+			expected: []svc.Account{{ID: "111111111111"}, {ID: "222222222222"}},
+		},
+		{
+			name:     "build accounts from file",
+			accounts: "file://" + path,
+			expected: []svc.Account{{ID: "111111111111"}, {ID: "222222222222"}},
+		},
+		{
+			name:      "specify invalid account id",
+			accounts:  "111111111111,production",
+			wantErr:   true,
+			expectErr: "invalid account id: 'production'",
+		},
+		{
+			name:      "specify undefined file",
+			accounts:  "file://" + filepath.Join(t.TempDir(), "undefined.txt"),
+			wantErr:   true,
+			expectErr: "no such file",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := buildAccounts(context.TODO(), aws.Config{}, svc.Partition{}, tt.accounts)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAnnotateAccount(t *testing.T) {
+	cases := []struct {
+		name     string
+		result   interface{}
+		expected interface{}
+	}{
+		{
+			name:   "annotate raw result",
+			result: map[string]string{"ClusterName": "production"},
+			expected: map[string]interface{}{
+				"ClusterName":  "production",
+				"AccountId":    "111111111111",
+				"AccountAlias": "example-production",
+			},
+		},
+		{
+			name:   "annotate envelope",
+			result: svc.Envelope{ID: "production", Account: "111111111111"},
+			expected: svc.Envelope{
+				ID:           "production",
+				Account:      "111111111111",
+				AccountAlias: "example-production",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resultList := &svc.ResultList{
+				Service:  "ecs",
+				Resource: "cluster",
+				Results:  []interface{}{tt.result},
+				Errors: []svc.QueryError{
+					{Region: "us-east-1", Message: "access denied"},
+				},
+			}
+
+			err := annotateAccount(resultList, "111111111111", "example-production")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(resultList.Results[0], tt.expected) {
+				t.Errorf("actual = %v, want = %v", resultList.Results[0], tt.expected)
+			}
+			if resultList.Errors[0].Account != "111111111111" {
+				t.Errorf("actual = %v, want = %v", resultList.Errors[0].Account, "111111111111")
+			}
+		})
+	}
+}
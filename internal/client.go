@@ -2,99 +2,708 @@ package internal
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jmespath/go-jmespath"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 
 	svc "github.com/thaim/awsresq/service"
 )
 
+// envelopeColumns are printed in tabular output for envelopes when no columns are specified.
+var envelopeColumns = []string{"region", "id", "name", "createdAt"}
+
+// ErrPartialResult is returned by Search when some regions failed but others returned results.
+var ErrPartialResult = errors.New("query failed in some regions")
+
+// ClientOption configures how AwsresqClient calls AWS APIs.
+type ClientOption struct {
+	// Timeout bounds a whole search across all regions.
+	Timeout time.Duration
+	// MaxRetries is the number of times a failed API call is retried.
+	MaxRetries int
+	// RetryMode is either "standard" or "adaptive". Empty means the SDK default.
+	RetryMode string
+	// MaxConcurrency limits the number of regions queried at the same time, across every resource and account searched.
+	MaxConcurrency int
+	// Output is one of OutputFormats. Empty means json.
+	Output string
+	// Columns are the dotted paths printed in tabular output. Empty means the resource's default columns.
+	Columns []string
+	// Query is a JMESPath expression applied to the result list before it is printed.
+	Query string
+	// Where is an expression evaluated against each result, and only the results it is true for are printed.
+	Where string
+	// SortBy are the dotted paths the results are ordered by, each prefixed with '-' for descending order.
+	// Results are ordered by region and then by resource ID after them.
+	SortBy []string
+	// Limit is the maximum number of results printed for each resource. Zero means no limit.
+	Limit int
+	// Tags selects resources having the tags, each in the form of "key=value" or "key".
+	Tags []string
+	// ExcludeTags selects resources not having the tags, each in the form of "key=value" or "key".
+	ExcludeTags []string
+	// Filters selects resources by their attributes, each in the form of "Name=name,Values=value1,value2".
+	Filters []string
+	// Partition is one of "aws", "aws-us-gov" and "aws-cn". Empty means the partition of the configured region.
+	Partition string
+	// ExcludeRegions are region names and glob patterns removed from the regions to search.
+	ExcludeRegions []string
+	// Credential selects the profile and the role used to call AWS APIs.
+	Credential CredentialOption
+	// Accounts enables multi-account mode. It is either "organization", a comma separated list of account IDs,
+	// or "file://" followed by a file listing account IDs. Empty means the account of the credentials.
+	Accounts string
+	// AccountRole is the name of the role assumed in each account. Empty means OrganizationAccountAccessRole.
+	AccountRole string
+	// Raw prints the results as returned by the AWS APIs instead of wrapping them in envelopes.
+	Raw bool
+}
+
 type AwsresqClient struct {
 	awsCfg aws.Config
 	Region []string
-	api    svc.AwsresqAPI
+	// services are the names of the services to search, and apis are keyed by them.
+	services []string
+	apis     map[string]svc.AwsresqAPI
+	output   string
+	columns  []string
+	query    *jmespath.JMESPath
+	where    *whereExpr
+	sortKeys []sortKey
+	limit    int
+	raw      bool
+	queryOpt svc.QueryOption
+
+	// accounts are only set in multi-account mode.
+	accounts []*accountAPI
+	timeout  time.Duration
 }
 
-func NewAwsresqClient(region, service string) (*AwsresqClient, error) {
-	client := &AwsresqClient{}
+// NewAwsresqClient builds a client searching the services in the regions.
+// Both are comma separated lists of names and glob patterns.
+func NewAwsresqClient(region, service string, opt ClientOption) (*AwsresqClient, error) {
+	client := &AwsresqClient{
+		output:  opt.Output,
+		columns: opt.Columns,
+		raw:     opt.Raw,
+		timeout: opt.Timeout,
+	}
+	if client.output == "" {
+		client.output = "json"
+	}
+	if !slices.Contains(OutputFormats, client.output) {
+		return nil, fmt.Errorf("output format not supported: %s", client.output)
+	}
+	if opt.Query != "" {
+		query, err := jmespath.Compile(opt.Query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		client.query = query
+	}
+	if opt.Where != "" {
+		where, err := parseWhere(opt.Where)
+		if err != nil {
+			return nil, fmt.Errorf("invalid where expression: %w", err)
+		}
+		client.where = where
+	}
+	if opt.Limit < 0 {
+		return nil, fmt.Errorf("invalid limit: %d", opt.Limit)
+	}
+	client.limit = opt.Limit
+	sortKeys, err := buildSortKeys(opt.SortBy)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Accounts != "" {
+		sortKeys = append(sortKeys, sortKey{path: "account"})
+	}
+	// raw results have neither region nor id, the services order them by ID within each region instead
+	if !opt.Raw {
+		sortKeys = append(sortKeys, defaultSortKeys...)
+	}
+	client.sortKeys = sortKeys
+	services, err := buildServices(service)
+	if err != nil {
+		return nil, err
+	}
+	client.services = services
 
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err := opt.Credential.validate(); err != nil {
+		return nil, err
+	}
+	var partition svc.Partition
+	if opt.Partition != "" {
+		p, err := svc.LookupPartition(opt.Partition)
+		if err != nil {
+			return nil, err
+		}
+		partition = p
+	}
+
+	loadOptions := []func(*config.LoadOptions) error{
+		config.WithRetryMaxAttempts(opt.MaxRetries + 1),
+	}
+	loadOptions = append(loadOptions, opt.Credential.loadOptions()...)
+	if opt.RetryMode != "" {
+		mode, err := aws.ParseRetryMode(opt.RetryMode)
+		if err != nil {
+			return nil, err
+		}
+		loadOptions = append(loadOptions, config.WithRetryMode(mode))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), loadOptions...)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "configuration error")
 		return nil, err
 	}
+	if opt.Partition == "" {
+		partition = svc.PartitionOfRegion(cfg.Region)
+	} else if svc.PartitionOfRegion(cfg.Region).ID != partition.ID {
+		// the configured region cannot be used with the credentials of another partition
+		cfg.Region = partition.DefaultRegion
+	}
+	opt.Credential.assumeRole(&cfg)
 	client.awsCfg = cfg
 
-	client.Region = buildRegion(region)
-
-	switch service {
-	case "cloudformation":
-		client.api = svc.NewAwsresqCloudformationAPI(client.awsCfg, client.Region)
-	case "cloudwatch":
-		client.api = svc.NewAwsresqCloudwatchAPI(client.awsCfg, client.Region)
-	case "config":
-		client.api = svc.NewAwsresqConfigAPI(client.awsCfg, client.Region)
-	case "ec2":
-		client.api = svc.NewAwsresqEc2API(client.awsCfg, client.Region)
-	case "ecr":
-		client.api = svc.NewAwsresqEcrAPI(client.awsCfg, client.Region)
-	case "ecs":
-		client.api = svc.NewAwsresqEcsAPI(client.awsCfg, client.Region)
-	case "efs":
-		client.api = svc.NewAwsresqEfsAPI(client.awsCfg, client.Region)
-	case "iam":
-		client.api = svc.NewAwsresqIamAPI(client.awsCfg, client.Region)
-	case "logs":
-		client.api = svc.NewAwsresqLogsAPI(client.awsCfg, client.Region)
-	case "lambda":
-		client.api = svc.NewAwsresqLambdaAPI(client.awsCfg, client.Region)
-	case "route53":
-		client.api = svc.NewAwsresqRoute53API(client.awsCfg, client.Region)
-	case "s3":
-		client.api = svc.NewAwsresqS3API(client.awsCfg, client.Region)
-	default:
-		log.Error().Msgf("service not supported: %s", service)
-		return nil, fmt.Errorf("service not supported: %s", service)
+	client.Region, err = buildRegion(region, opt.ExcludeRegions, func() ([]string, []string) {
+		return availableRegions(client.awsCfg, partition, opt.Timeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tagFilters, err := buildTagFilters(opt.Tags, opt.ExcludeTags)
+	if err != nil {
+		return nil, err
+	}
+	filters, err := buildFilters(opt.Filters)
+	if err != nil {
+		return nil, err
+	}
+	// the limiter is shared by every target and account, so that MaxConcurrency bounds the regions queried in total
+	queryOpt := svc.QueryOption{
+		Timeout:        opt.Timeout,
+		MaxConcurrency: opt.MaxConcurrency,
+		Limiter:        svc.NewLimiter(opt.MaxConcurrency),
+		TagFilters:     tagFilters,
+		Filters:        filters,
+		Partition:      partition,
+		Clients:        svc.NewClientCache(),
+		Envelope:       !opt.Raw,
+		OrderByID:      opt.Raw,
+	}
+	client.queryOpt = queryOpt
+
+	client.apis, err = newServiceAPIs(services, client.awsCfg, client.Region, queryOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Accounts != "" {
+		client.accounts, err = buildAccountAPIs(services, client.awsCfg, client.Region, queryOpt, opt)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return client, nil
 }
 
-// Validate check if the resource is supported in the service
-func (c *AwsresqClient) Validate(resource string) bool {
-	return c.api.Validate(resource)
+// Validate checks if the resource option selects a resource of the services, and if they support the filters.
+func (c *AwsresqClient) Validate(resource string) error {
+	_, err := c.targets(resource)
+	return err
 }
 
-func (c *AwsresqClient) Search(service, resource string) (string, error) {
-	var resultList *svc.ResultList
-	resultList, err := c.api.Query(resource)
+// targets resolves the resource option into the resources to search, which must support the filters.
+func (c *AwsresqClient) targets(resource string) ([]target, error) {
+	targets, err := buildTargets(c.services, resource)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range targets {
+		def, err := svc.LookupService(t.service)
+		if err != nil {
+			return nil, err
+		}
+		if err := def.ValidateFilters(t.resource, c.queryOpt.Filters); err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+// Search queries the resources and returns the result rendered in the output format.
+// The resource option is a comma separated list of resource names and glob patterns,
+// and every resource of the services matching it is queried concurrently within the timeout of the client.
+// A single resource is rendered as a result list, and several resources as a list of result lists.
+// The result is returned along with the error when the query failed partially or in every region,
+// so that the caller can still report what succeeded and which regions failed.
+func (c *AwsresqClient) Search(resource string) (string, error) {
+	targets, err := c.targets(resource)
+	if err != nil {
+		return "", err
+	}
+	return c.search(targets, nil)
+}
+
+// Find queries the resources and returns those whose ID, name, ARN or tag values match the term,
+// rendered in the output format as a list of result lists.
+// The term is searched as a case insensitive substring, or as a regular expression when regex is true.
+// The resource option selects the resources to query as in Search, and every resource is queried when it is empty.
+func (c *AwsresqClient) Find(resource, term string, regex bool) (string, error) {
+	if c.raw {
+		return "", errors.New("raw results cannot be searched, find matches the fields of envelopes")
+	}
+	matchTerm, err := newTermMatcher(term, regex)
+	if err != nil {
+		return "", err
+	}
+	if resource == "" {
+		resource = findResources
+	}
+	targets, err := c.targets(resource)
 	if err != nil {
 		return "", err
 	}
+	return c.search(targets, func(result interface{}) bool {
+		return matchEnvelope(result, matchTerm)
+	})
+}
+
+// Get queries the resource identified by the ARN and returns it rendered in the output format.
+// The resource is described with a targeted API when it has one, and in the region of the ARN if any.
+// In multi-account mode, it is queried in the account of the ARN, which must be one of the accounts.
+// The client must be in the partition of the ARN, such as by passing it as ClientOption.Partition.
+func (c *AwsresqClient) Get(resourceARN svc.ResourceARN) (string, error) {
+	if resourceARN.Partition != c.queryOpt.Partition.ID {
+		return "", fmt.Errorf("ARN in partition %s cannot be got in partition %s", resourceARN.Partition, c.queryOpt.Partition.ID)
+	}
+	def, err := svc.LookupService(resourceARN.Service)
+	if err != nil {
+		return "", err
+	}
+	region := c.Region
+	if resourceARN.Region != "" {
+		region = []string{resourceARN.Region}
+	}
+	opt := c.queryOpt
+	opt.ARN = &resourceARN
+
+	ctx, cancel := c.searchContext()
+	defer cancel()
+	var resultList *svc.ResultList
+	if len(c.accounts) > 0 {
+		a, err := c.lookupAccount(resourceARN.AccountID)
+		if err != nil {
+			return "", err
+		}
+		opt.Account = a.account.ID
+		resultList, err = def.New(a.awsCfg, region, opt).QueryContext(ctx, resourceARN.Resource)
+		if resultList != nil {
+			if err := annotateAccount(resultList, a.account.ID, a.lookupAlias(ctx)); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		resultList, err = def.New(c.awsCfg, region, opt).QueryContext(ctx, resourceARN.Resource)
+	}
+	if resultList == nil {
+		return "", err
+	}
+
+	if len(resultList.Results) == 0 {
+		if len(resultList.Errors) > 0 {
+			return "", fmt.Errorf("failed to get %s: %s", resourceARN.String(), resultList.Errors[0].Message)
+		}
+		return "", fmt.Errorf("resource not found: %s", resourceARN.String())
+	}
+
+	columns := c.columns
+	if len(columns) == 0 && c.query == nil {
+		columns = c.defaultColumns(resourceARN.Service, resourceARN.Resource, false)
+	}
+	return formatObject(resultList.Results[0], c.output, columns, c.query)
+}
+
+// lookupAccount returns the account searched in multi-account mode with the ID.
+func (c *AwsresqClient) lookupAccount(id string) (*accountAPI, error) {
+	if id == "" {
+		return nil, errors.New("ARN without account cannot be got in multiple accounts")
+	}
+	for _, a := range c.accounts {
+		if a.account.ID == id {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("account %s is not one of the accounts searched", id)
+}
+
+// searchContext returns the context bounding a search by the timeout of the client.
+func (c *AwsresqClient) searchContext() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(context.Background(), c.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// search queries the targets and renders their results, keeping only the results matching when match is given.
+// Results are also filtered by the where expression of the client, which keeps the result lists left empty,
+// and then sorted and limited.
+func (c *AwsresqClient) search(targets []target, match func(interface{}) bool) (string, error) {
+	ctx, cancel := c.searchContext()
+	defer cancel()
+	resultLists, errs := c.searchTargets(ctx, targets)
+	if len(targets) == 1 && resultLists[0] == nil {
+		return "", errs[0]
+	}
+
+	failed := 0
+	for i, resultList := range resultLists {
+		if resultList == nil {
+			resultLists[i] = &svc.ResultList{
+				Service:  targets[i].service,
+				Resource: targets[i].resource,
+				Results:  []interface{}{},
+				Errors:   []svc.QueryError{{Message: errs[i].Error()}},
+			}
+		}
+		if errs[i] != nil {
+			failed++
+		}
+	}
 
-	res, err := json.MarshalIndent(resultList, "", "  ")
+	if c.where != nil {
+		c.where.filter(resultLists)
+	}
+
+	// errors are still reported when nothing was found
+	allResultLists := resultLists
+	several := len(targets) > 1
+	if match != nil {
+		resultLists = filterResults(resultLists, match)
+		several = true
+	}
+	if err := sortResults(resultLists, c.sortKeys); err != nil {
+		return "", err
+	}
+	limitResults(resultLists, c.limit)
+	res, err := c.format(resultLists, several)
 	if err != nil {
 		return "", err
 	}
 
-	return string(res), nil
+	// json and yaml include the errors in the result itself
+	partial := false
+	for _, resultList := range allResultLists {
+		partial = partial || len(resultList.Errors) > 0
+		if c.output == "json" || c.output == "yaml" {
+			continue
+		}
+		for _, e := range resultList.Errors {
+			if e.Account != "" {
+				fmt.Fprintf(os.Stderr, "error in account %s region %s: %s %s: %s\n", e.Account, e.Region, e.Operation, e.Code, e.Message)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "error in region %s: %s %s: %s\n", e.Region, e.Operation, e.Code, e.Message)
+		}
+	}
+
+	if failed == len(targets) {
+		if len(targets) == 1 {
+			return res, errs[0]
+		}
+		return res, svc.ErrQueryFailed
+	}
+	if partial {
+		return res, ErrPartialResult
+	}
+	return res, nil
+}
+
+// searchTargets queries the targets concurrently.
+// Their region queries share the limiter of the query option, which bounds them all by the max concurrency.
+// The result list of a target is nil when it could not be queried at all.
+func (c *AwsresqClient) searchTargets(ctx context.Context, targets []target) ([]*svc.ResultList, []error) {
+	resultLists := make([]*svc.ResultList, len(targets))
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			if len(c.accounts) > 0 {
+				resultLists[i], errs[i] = searchAccounts(ctx, c.accounts, t.service, t.resource)
+				return
+			}
+			resultLists[i], errs[i] = c.apis[t.service].QueryContext(ctx, t.resource)
+		}(i, t)
+	}
+	wg.Wait()
+
+	return resultLists, errs
+}
+
+// format renders the result lists in the output format, as a list of result lists when several is true.
+// Raw results of several resources are printed as a table per resource in tabular formats,
+// as their fields differ from each other.
+func (c *AwsresqClient) format(resultLists []*svc.ResultList, several bool) (string, error) {
+	if !several {
+		resultList := resultLists[0]
+		columns := c.columns
+		if len(columns) == 0 && c.query == nil {
+			columns = c.defaultColumns(resultList.Service, resultList.Resource, false)
+		}
+		return formatResult(resultList, c.output, columns, c.query)
+	}
+
+	if c.raw && len(c.columns) == 0 && c.query == nil && isTabular(c.output) {
+		tables := make([]string, 0, len(resultLists))
+		for _, resultList := range resultLists {
+			columns := c.defaultColumns(resultList.Service, resultList.Resource, false)
+			table, err := formatResult(resultList, c.output, columns, nil)
+			if err != nil {
+				return "", err
+			}
+			tables = append(tables, fmt.Sprintf("# %s %s\n%s", resultList.Service, resultList.Resource, table))
+		}
+		return strings.Join(tables, "\n\n"), nil
+	}
+
+	columns := c.columns
+	if len(columns) == 0 && c.query == nil {
+		columns = c.defaultColumns("", "", true)
+	}
+	return formatResults(resultLists, c.output, columns, c.query)
+}
+
+// defaultColumns returns the columns printed in tabular output when no columns are specified.
+// Envelopes are printed with the same columns for every resource, along with the resource when several are printed.
+func (c *AwsresqClient) defaultColumns(service, resource string, several bool) []string {
+	var columns []string
+	if !c.raw {
+		columns = envelopeColumns
+		if several {
+			columns = append([]string{"service", "resource"}, columns...)
+		}
+		if len(c.accounts) > 0 {
+			columns = append([]string{"account"}, columns...)
+		}
+		return columns
+	}
+
+	columns = c.apis[service].DefaultColumns(resource)
+	if len(c.accounts) > 0 {
+		columns = append([]string{"AccountId"}, columns...)
+	}
+	return columns
+}
+
+// newServiceAPIs builds the APIs of the services keyed by service name.
+func newServiceAPIs(services []string, cfg aws.Config, region []string, opt svc.QueryOption) (map[string]svc.AwsresqAPI, error) {
+	apis := make(map[string]svc.AwsresqAPI, len(services))
+	for _, service := range services {
+		def, err := svc.LookupService(service)
+		if err != nil {
+			log.Error().Msgf("service not supported: %s", service)
+			return nil, err
+		}
+		apis[def.Name] = def.New(cfg, region, opt)
+	}
+	return apis, nil
+}
+
+// buildAccountAPIs builds the APIs of services in each account, assuming the account role with the credentials of cfg.
+func buildAccountAPIs(services []string, cfg aws.Config, region []string, queryOpt svc.QueryOption, opt ClientOption) ([]*accountAPI, error) {
+	ctx := context.Background()
+	if opt.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opt.Timeout)
+		defer cancel()
+	}
+	accounts, err := buildAccounts(ctx, cfg, queryOpt.Partition, opt.Accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	role := opt.AccountRole
+	if role == "" {
+		role = defaultAccountRole
+	}
+
+	accountAPIs := make([]*accountAPI, 0, len(accounts))
+	for _, account := range accounts {
+		accountCfg := cfg.Copy()
+		CredentialOption{
+			RoleArn:     queryOpt.Partition.ARN("iam", "", account.ID, "role/"+role),
+			ExternalID:  opt.Credential.ExternalID,
+			SessionName: opt.Credential.SessionName,
+		}.assumeRole(&accountCfg)
+
+		accountOpt := queryOpt
+		accountOpt.Account = account.ID
+		apis, err := newServiceAPIs(services, accountCfg, region, accountOpt)
+		if err != nil {
+			return nil, err
+		}
+		accountAPIs = append(accountAPIs, &accountAPI{
+			account:   account,
+			awsCfg:    accountCfg,
+			partition: queryOpt.Partition,
+			apis:      apis,
+		})
+	}
+
+	return accountAPIs, nil
 }
 
-func buildRegion(region string) []string {
-	if region == "all" || region == "" {
-		return []string{
-			"us-east-1", "us-east-2", "us-west-1", "us-west-2",
-			"ap-south-1", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-southeast-1", "ap-southeast-2",
-			"ca-central-1",
-			"eu-central-1", "eu-west-1", "eu-west-2", "eu-west-3", "eu-north-1",
-			"sa-east-1",
+// buildRegion resolves the region option into the regions to search.
+// The option is a comma separated list of region names and glob patterns such as "eu-*".
+// "all" or empty selects every available region, and patterns are matched against the available regions.
+// available is only called when the option needs it. It also returns the opt-in regions not known to be enabled,
+// which a pattern matching no available region is matched against instead.
+func buildRegion(region string, excludeRegions []string, available func() (regions, optInRegions []string)) ([]string, error) {
+	if region == "" {
+		region = "all"
+	}
+
+	var availableRegions, optInRegions []string
+	var regions []string
+	for _, r := range strings.Split(region, ",") {
+		if r != "all" && !isPattern(r) {
+			regions = appendUnique(regions, r)
+			continue
+		}
+
+		if availableRegions == nil {
+			availableRegions, optInRegions = available()
+		}
+		matched, err := appendMatchedRegions(&regions, r, availableRegions)
+		if err != nil {
+			return nil, err
+		}
+		if !matched && r != "all" {
+			matched, err = appendMatchedRegions(&regions, r, optInRegions)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				log.Warn().Msgf("region pattern '%s' only matches opt-in regions, which may not be enabled in the account", r)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("region pattern matches no region: '%s'", r)
+		}
+	}
+
+	result := make([]string, 0, len(regions))
+	for _, r := range regions {
+		excluded := false
+		for _, e := range excludeRegions {
+			ok, err := matchRegion(e, r)
+			if err != nil {
+				return nil, err
+			}
+			excluded = excluded || ok
+		}
+		if !excluded {
+			result = append(result, r)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("no region to search")
+	}
+
+	return result, nil
+}
+
+// appendMatchedRegions appends the candidates matching the pattern to regions, and reports whether any matched.
+func appendMatchedRegions(regions *[]string, pattern string, candidates []string) (bool, error) {
+	matched := false
+	for _, c := range candidates {
+		ok, err := matchRegion(pattern, c)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			*regions = appendUnique(*regions, c)
+			matched = true
+		}
+	}
+	return matched, nil
+}
+
+func matchRegion(pattern, region string) (bool, error) {
+	if pattern == "all" {
+		return true, nil
+	}
+	ok, err := path.Match(pattern, region)
+	if err != nil {
+		return false, fmt.Errorf("invalid region pattern: '%s'", pattern)
+	}
+	return ok, nil
+}
+
+// availableRegions returns the regions enabled in the account.
+// When they cannot be described, the regions enabled by default in partition are returned instead,
+// along with the opt-in regions of partition, which may or may not be enabled in the account.
+func availableRegions(cfg aws.Config, partition svc.Partition, timeout time.Duration) ([]string, []string) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	regions, err := svc.DescribeRegions(ctx, cfg, partition)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to describe regions, falling back to the default regions")
+		return partition.Regions, partition.OptInRegions
+	}
+	return regions, nil
+}
+
+// buildFilters parses the filters, each in the syntax of the AWS CLI.
+func buildFilters(exprs []string) (svc.Filters, error) {
+	var filters svc.Filters
+	for _, expr := range exprs {
+		f, err := svc.ParseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func buildTagFilters(tags, excludeTags []string) (svc.TagFilters, error) {
+	var filters svc.TagFilters
+	for _, tag := range tags {
+		f, err := svc.ParseTagFilter(tag, false)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	for _, tag := range excludeTags {
+		f, err := svc.ParseTagFilter(tag, true)
+		if err != nil {
+			return nil, err
 		}
+		filters = append(filters, f)
 	}
 
-	return strings.Split(region, ",")
+	return filters, nil
 }
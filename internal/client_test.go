@@ -11,6 +11,7 @@ func TestNewAwsresqClient(t *testing.T) {
 		name      string
 		region    string
 		service   string
+		opt       ClientOption
 		wantErr   bool
 		expectErr string
 	}{
@@ -19,9 +20,114 @@ func TestNewAwsresqClient(t *testing.T) {
 			service: "ecs",
 			wantErr: false,
 		},
+		{
+			name:    "initialize client with retry options",
+			service: "ecs",
+			opt: ClientOption{
+				MaxRetries: 5,
+				RetryMode:  "adaptive",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "specify undefined retry mode",
+			service: "ecs",
+			opt: ClientOption{
+				RetryMode: "custom",
+			},
+			wantErr:   true,
+			expectErr: "unknown RetryMode",
+		},
+		{
+			name:    "specify undefined output format",
+			service: "ecs",
+			opt: ClientOption{
+				Output: "xml",
+			},
+			wantErr:   true,
+			expectErr: "output format not supported: xml",
+		},
+		{
+			name:    "specify invalid query",
+			service: "ecs",
+			opt: ClientOption{
+				Query: "results[?",
+			},
+			wantErr:   true,
+			expectErr: "invalid query",
+		},
+		{
+			name:    "specify invalid where expression",
+			service: "ecs",
+			opt: ClientOption{
+				Where: "raw.RunningCount <",
+			},
+			wantErr:   true,
+			expectErr: "invalid where expression",
+		},
+		{
+			name:    "specify negative limit",
+			service: "ecs",
+			opt: ClientOption{
+				Limit: -1,
+			},
+			wantErr:   true,
+			expectErr: "invalid limit: -1",
+		},
+		{
+			name:    "initialize client with role",
+			service: "ecs",
+			opt: ClientOption{
+				Credential: CredentialOption{
+					RoleArn:     "arn:aws:iam::123456789012:role/awsresq",
+					ExternalID:  "example",
+					SessionName: "awsresq",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "specify external id without role",
+			service: "ecs",
+			opt: ClientOption{
+				Credential: CredentialOption{
+					ExternalID: "example",
+				},
+			},
+			wantErr:   true,
+			expectErr: "require role arn",
+		},
+		{
+			name:    "specify undefined profile",
+			service: "ecs",
+			opt: ClientOption{
+				Credential: CredentialOption{
+					Profile: "awsresq-undefined-profile",
+				},
+			},
+			wantErr:   true,
+			expectErr: "awsresq-undefined-profile",
+		},
+		{
+			name:    "initialize client with partition",
+			service: "iam",
+			opt: ClientOption{
+				Partition: "aws-us-gov",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "specify undefined partition",
+			service: "iam",
+			opt: ClientOption{
+				Partition: "aws-iso",
+			},
+			wantErr:   true,
+			expectErr: "partition not supported: aws-iso",
+		},
 		{
 			name:      "specify undefined service",
-			region:    "all",
+			region:    "ap-northeast-1",
 			service:   "custom",
 			wantErr:   true,
 			expectErr: "service not supported: custom",
@@ -30,7 +136,11 @@ func TestNewAwsresqClient(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual, err := NewAwsresqClient(tt.region, tt.service)
+			// regions are specified so that the test does not describe the regions of an account
+			if tt.region == "" {
+				tt.region = "ap-northeast-1"
+			}
+			actual, err := NewAwsresqClient(tt.region, tt.service, tt.opt)
 
 			if tt.wantErr {
 				if err == nil {
@@ -51,16 +161,65 @@ func TestNewAwsresqClient(t *testing.T) {
 	}
 }
 
+func TestNewAwsresqClientSortKeys(t *testing.T) {
+	cases := []struct {
+		name              string
+		opt               ClientOption
+		expectedKeys      []sortKey
+		expectedOrderByID bool
+	}{
+		{
+			name:         "sort envelopes by the keys given and then by region and id",
+			opt:          ClientOption{SortBy: []string{"-createdAt"}},
+			expectedKeys: []sortKey{{path: "createdAt", desc: true}, {path: "region"}, {path: "id"}},
+		},
+		{
+			name:              "sort raw results by the keys given and leave the order by id to the services",
+			opt:               ClientOption{SortBy: []string{"-MemorySize"}, Raw: true},
+			expectedKeys:      []sortKey{{path: "MemorySize", desc: true}},
+			expectedOrderByID: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := NewAwsresqClient("ap-northeast-1", "lambda", tt.opt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.sortKeys, tt.expectedKeys) {
+				t.Errorf("expected sort keys %v, but got %v", tt.expectedKeys, actual.sortKeys)
+			}
+			if actual.queryOpt.OrderByID != tt.expectedOrderByID {
+				t.Errorf("expected OrderByID %v, but got %v", tt.expectedOrderByID, actual.queryOpt.OrderByID)
+			}
+		})
+	}
+}
+
 func TestBuildRegion(t *testing.T) {
+	available := []string{"ap-northeast-1", "ap-northeast-3", "eu-central-1", "eu-west-1", "il-central-1", "us-east-1"}
+
 	cases := []struct {
-		name     string
-		input    string
-		expected []string
+		name    string
+		input   string
+		exclude []string
+		// optIn are the opt-in regions returned when the regions of the account cannot be described
+		optIn     []string
+		expected  []string
+		wantErr   bool
+		expectErr string
 	}{
 		{
 			name:     "build regions from all",
 			input:    "all",
-			expected: []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ap-south-1", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-west-1", "eu-west-2", "eu-west-3", "eu-north-1", "sa-east-1"},
+			expected: available,
+		},
+		{
+			name:     "build regions from empty",
+			input:    "",
+			expected: available,
 		},
 		{
 			name:     "specify single region",
@@ -72,12 +231,84 @@ func TestBuildRegion(t *testing.T) {
 			input:    "ap-northeast-1,us-east-1,us-west-1",
 			expected: []string{"ap-northeast-1", "us-east-1", "us-west-1"},
 		},
+		{
+			name:     "specify region pattern",
+			input:    "eu-*",
+			expected: []string{"eu-central-1", "eu-west-1"},
+		},
+		{
+			name:     "specify region pattern with duplicated region",
+			input:    "eu-west-1,eu-*",
+			expected: []string{"eu-west-1", "eu-central-1"},
+		},
+		{
+			name:     "exclude regions",
+			input:    "all",
+			exclude:  []string{"ap-*", "il-central-1"},
+			expected: []string{"eu-central-1", "eu-west-1", "us-east-1"},
+		},
+		{
+			name:      "specify region pattern matching no region",
+			input:     "sa-*",
+			wantErr:   true,
+			expectErr: "region pattern matches no region: 'sa-*'",
+		},
+		{
+			name:     "specify region pattern matching only opt-in regions",
+			input:    "af-*,me-*",
+			optIn:    []string{"af-south-1", "me-central-1", "me-south-1"},
+			expected: []string{"af-south-1", "me-central-1", "me-south-1"},
+		},
+		{
+			name:     "specify region pattern matching available regions before opt-in regions",
+			input:    "eu-*",
+			optIn:    []string{"eu-south-1"},
+			expected: []string{"eu-central-1", "eu-west-1"},
+		},
+		{
+			name:     "build regions from all without opt-in regions",
+			input:    "all",
+			optIn:    []string{"af-south-1"},
+			expected: available,
+		},
+		{
+			name:      "specify region pattern matching neither available nor opt-in regions",
+			input:     "sa-*",
+			optIn:     []string{"af-south-1"},
+			wantErr:   true,
+			expectErr: "region pattern matches no region: 'sa-*'",
+		},
+		{
+			name:      "specify invalid region pattern",
+			input:     "all",
+			exclude:   []string{"eu-[*"},
+			wantErr:   true,
+			expectErr: "invalid region pattern: 'eu-[*'",
+		},
+		{
+			name:      "exclude every region",
+			input:     "us-east-1",
+			exclude:   []string{"us-*"},
+			wantErr:   true,
+			expectErr: "no region to search",
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := buildRegion(tt.input)
+			actual, err := buildRegion(tt.input, tt.exclude, func() ([]string, []string) { return available, tt.optIn })
 
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
 			if !reflect.DeepEqual(actual, tt.expected) {
 				t.Errorf("actual = %v, want = %v", actual, tt.expected)
 			}
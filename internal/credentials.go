@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// CredentialOption selects the credentials used to call AWS APIs.
+type CredentialOption struct {
+	// Profile is the name of the shared config profile. Empty means the default profile or AWS_PROFILE.
+	Profile string
+	// RoleArn is the role assumed with the credentials of Profile.
+	RoleArn string
+	// ExternalID is passed to AssumeRole when the role requires it.
+	ExternalID string
+	// MFASerial is the MFA device whose token code is read from stdin on AssumeRole.
+	MFASerial string
+	// SessionName is the role session name. Empty means a name generated by the SDK.
+	SessionName string
+}
+
+func (opt CredentialOption) validate() error {
+	if opt.RoleArn != "" {
+		return nil
+	}
+	if opt.ExternalID != "" || opt.MFASerial != "" || opt.SessionName != "" {
+		return errors.New("external id, mfa serial and session name require role arn")
+	}
+	return nil
+}
+
+func (opt CredentialOption) loadOptions() []func(*config.LoadOptions) error {
+	var loadOptions []func(*config.LoadOptions) error
+	if opt.Profile != "" {
+		loadOptions = append(loadOptions, config.WithSharedConfigProfile(opt.Profile))
+	}
+	return loadOptions
+}
+
+// assumeRole replaces the credentials of cfg with those of RoleArn, if any.
+func (opt CredentialOption) assumeRole(cfg *aws.Config) {
+	if opt.RoleArn == "" {
+		return
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(*cfg), opt.RoleArn, opt.assumeRoleOptions)
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+}
+
+func (opt CredentialOption) assumeRoleOptions(o *stscreds.AssumeRoleOptions) {
+	if opt.ExternalID != "" {
+		o.ExternalID = aws.String(opt.ExternalID)
+	}
+	if opt.MFASerial != "" {
+		o.SerialNumber = aws.String(opt.MFASerial)
+		o.TokenProvider = stscreds.StdinTokenProvider
+	}
+	if opt.SessionName != "" {
+		o.RoleSessionName = opt.SessionName
+	}
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+)
+
+func TestAssumeRoleOptions(t *testing.T) {
+	cases := []struct {
+		name     string
+		opt      CredentialOption
+		expected stscreds.AssumeRoleOptions
+		hasToken bool
+	}{
+		{
+			name:     "assume role without options",
+			opt:      CredentialOption{RoleArn: "arn:aws:iam::123456789012:role/awsresq"},
+			expected: stscreds.AssumeRoleOptions{},
+		},
+		{
+			name: "assume role with external id and session name",
+			opt: CredentialOption{
+				RoleArn:     "arn:aws:iam::123456789012:role/awsresq",
+				ExternalID:  "example",
+				SessionName: "awsresq",
+			},
+			expected: stscreds.AssumeRoleOptions{
+				ExternalID:      aws.String("example"),
+				RoleSessionName: "awsresq",
+			},
+		},
+		{
+			name: "assume role with mfa",
+			opt: CredentialOption{
+				RoleArn:   "arn:aws:iam::123456789012:role/awsresq",
+				MFASerial: "arn:aws:iam::123456789012:mfa/user",
+			},
+			expected: stscreds.AssumeRoleOptions{
+				SerialNumber: aws.String("arn:aws:iam::123456789012:mfa/user"),
+			},
+			hasToken: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := stscreds.AssumeRoleOptions{}
+			tt.opt.assumeRoleOptions(&actual)
+
+			if aws.ToString(actual.ExternalID) != aws.ToString(tt.expected.ExternalID) {
+				t.Errorf("actual = %v, want = %v", aws.ToString(actual.ExternalID), aws.ToString(tt.expected.ExternalID))
+			}
+			if aws.ToString(actual.SerialNumber) != aws.ToString(tt.expected.SerialNumber) {
+				t.Errorf("actual = %v, want = %v", aws.ToString(actual.SerialNumber), aws.ToString(tt.expected.SerialNumber))
+			}
+			if actual.RoleSessionName != tt.expected.RoleSessionName {
+				t.Errorf("actual = %v, want = %v", actual.RoleSessionName, tt.expected.RoleSessionName)
+			}
+			if (actual.TokenProvider != nil) != tt.hasToken {
+				t.Errorf("expected token provider %v, but got %v", tt.hasToken, actual.TokenProvider != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+// findResources is the resource option of Find when no resource is specified.
+const findResources = "*"
+
+// newTermMatcher returns a function reporting whether a value matches the search term.
+// The term matches values containing it regardless of case, or values matching it as a regular expression.
+func newTermMatcher(term string, regex bool) (func(string) bool, error) {
+	if term == "" {
+		return nil, errors.New("search term is empty")
+	}
+	if regex {
+		re, err := regexp.Compile(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search term: %w", err)
+		}
+		return re.MatchString, nil
+	}
+
+	term = strings.ToLower(term)
+	return func(s string) bool {
+		return strings.Contains(strings.ToLower(s), term)
+	}, nil
+}
+
+// matchEnvelope reports whether the ID, the name, the ARN or one of the tag values of the resource matches.
+// Results which are not envelopes never match.
+func matchEnvelope(result interface{}, match func(string) bool) bool {
+	envelope, ok := result.(svc.Envelope)
+	if !ok {
+		return false
+	}
+	for _, s := range []string{envelope.ID, envelope.Name, envelope.ARN} {
+		if s != "" && match(s) {
+			return true
+		}
+	}
+	for _, v := range envelope.Tags {
+		if v != "" && match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterResults removes the results not matching from the result lists.
+// Result lists left without results nor errors are removed, so that only the resources found are printed.
+func filterResults(resultLists []*svc.ResultList, match func(interface{}) bool) []*svc.ResultList {
+	filtered := make([]*svc.ResultList, 0, len(resultLists))
+	for _, resultList := range resultLists {
+		results := []interface{}{}
+		for _, result := range resultList.Results {
+			if match(result) {
+				results = append(results, result)
+			}
+		}
+		if len(results) == 0 && len(resultList.Errors) == 0 {
+			continue
+		}
+		resultList.Results = results
+		filtered = append(filtered, resultList)
+	}
+	return filtered
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+func TestMatchEnvelope(t *testing.T) {
+	envelope := svc.Envelope{
+		ARN:  "arn:aws:ec2:ap-northeast-1:012345678901:security-group/sg-0abc1234",
+		ID:   "sg-0abc1234",
+		Name: "payments-api",
+		Tags: map[string]string{"team": "Checkout"},
+	}
+
+	cases := []struct {
+		name      string
+		term      string
+		regex     bool
+		result    interface{}
+		expected  bool
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "match id as substring",
+			term:     "sg-0abc",
+			result:   envelope,
+			expected: true,
+		},
+		{
+			name:     "match name as substring",
+			term:     "payments-api",
+			result:   envelope,
+			expected: true,
+		},
+		{
+			name:     "match arn as substring",
+			term:     ":012345678901:",
+			result:   envelope,
+			expected: true,
+		},
+		{
+			name:     "match tag value regardless of case",
+			term:     "checkout",
+			result:   envelope,
+			expected: true,
+		},
+		{
+			name:     "ignore tag key",
+			term:     "team",
+			result:   envelope,
+			expected: false,
+		},
+		{
+			name:     "match regular expression",
+			term:     `^payments-(api|worker)$`,
+			regex:    true,
+			result:   envelope,
+			expected: true,
+		},
+		{
+			name:     "regular expression is case sensitive",
+			term:     `^checkout$`,
+			regex:    true,
+			result:   envelope,
+			expected: false,
+		},
+		{
+			name:     "raw result never matches",
+			term:     "sg-0abc",
+			result:   map[string]interface{}{"GroupId": "sg-0abc1234"},
+			expected: false,
+		},
+		{
+			name:      "invalid regular expression",
+			term:      "payments-(",
+			regex:     true,
+			wantErr:   true,
+			expectErr: "invalid search term",
+		},
+		{
+			name:      "empty term",
+			term:      "",
+			wantErr:   true,
+			expectErr: "search term is empty",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := newTermMatcher(tt.term, tt.regex)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := matchEnvelope(tt.result, match); actual != tt.expected {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	match, _ := newTermMatcher("prod", false)
+	resultLists := []*svc.ResultList{
+		{
+			Service:  "ecs",
+			Resource: "cluster",
+			Results: []interface{}{
+				svc.Envelope{ID: "production"},
+				svc.Envelope{ID: "staging"},
+			},
+		},
+		{
+			Service:  "lambda",
+			Resource: "function",
+			Results: []interface{}{
+				svc.Envelope{ID: "handler"},
+			},
+		},
+		{
+			Service:  "s3",
+			Resource: "bucket",
+			Results:  []interface{}{},
+			Errors:   []svc.QueryError{{Region: "us-east-1", Message: "access denied"}},
+		},
+	}
+
+	actual := filterResults(resultLists, func(result interface{}) bool {
+		return matchEnvelope(result, match)
+	})
+
+	expected := []*svc.ResultList{
+		{
+			Service:  "ecs",
+			Resource: "cluster",
+			Results: []interface{}{
+				svc.Envelope{ID: "production"},
+			},
+		},
+		{
+			Service:  "s3",
+			Resource: "bucket",
+			Results:  []interface{}{},
+			Errors:   []svc.QueryError{{Region: "us-east-1", Message: "access denied"}},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("actual = %+v, want = %+v", actual, expected)
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	svc "github.com/thaim/awsresq/service"
+)
+
+// ListServices renders the supported services in the output format.
+func ListServices(output string) (string, error) {
+	var rows []interface{}
+	for _, def := range svc.Services() {
+		rows = append(rows, map[string]interface{}{
+			"Service":     def.Name,
+			"Aliases":     def.Aliases,
+			"Description": def.Description,
+		})
+	}
+
+	return formatList(rows, output, []string{"Service", "Aliases", "Description"})
+}
+
+// ListResources renders the resources of service in the output format.
+// The resources of every service are rendered when service is empty.
+func ListResources(service, output string) (string, error) {
+	services := svc.Services()
+	if service != "" {
+		def, err := svc.LookupService(service)
+		if err != nil {
+			return "", err
+		}
+		services = []*svc.ServiceDefinition{def}
+	}
+
+	var rows []interface{}
+	for _, def := range services {
+		for _, r := range def.Resources {
+			rows = append(rows, map[string]interface{}{
+				"Service":     def.Name,
+				"Resource":    r.Name,
+				"Description": r.Description,
+				"Columns":     r.Columns,
+			})
+		}
+	}
+
+	return formatList(rows, output, []string{"Service", "Resource", "Description"})
+}
+
+func formatList(rows []interface{}, output string, columns []string) (string, error) {
+	if output == "" {
+		output = "table"
+	}
+	// convert into generic values so that lists are rendered the same as in search results
+	generic, err := toGeneric(rows)
+	if err != nil {
+		return "", err
+	}
+	rows, _ = generic.([]interface{})
+
+	return formatData(rows, rows, output, columns)
+}
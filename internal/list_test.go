@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestListResources(t *testing.T) {
+	cases := []struct {
+		name      string
+		service   string
+		output    string
+		expected  string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:    "list resources of service as table",
+			service: "ecs",
+			output:  "table",
+			expected: "Service  Resource         Description\n" +
+				"ecs      cluster          ECS clusters\n" +
+				"ecs      service          ECS services of every cluster\n" +
+				"ecs      task             ECS tasks of every cluster\n" +
+				"ecs      task-definition  ECS task definitions",
+		},
+		{
+			name:     "list resources of service alias as csv",
+			service:  "cloudwatchlogs",
+			output:   "csv",
+			expected: "Service,Resource,Description\nlogs,log-group,CloudWatch Logs log groups",
+		},
+		{
+			name:      "list resources of undefined service",
+			service:   "custom",
+			wantErr:   true,
+			expectErr: "service not supported: custom",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ListResources(tt.service, tt.output)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%v'", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("actual = %q, want = %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestListServices(t *testing.T) {
+	actual, err := ListServices("csv")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(actual, "Service,Aliases,Description\n") {
+		t.Errorf("unexpected header: %q", actual)
+	}
+	if !strings.Contains(actual, "\nlogs,cloudwatchlogs,Amazon CloudWatch Logs") {
+		t.Errorf("expected logs service, but got %q", actual)
+	}
+}
@@ -0,0 +1,328 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jmespath/go-jmespath"
+	"gopkg.in/yaml.v3"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+// OutputFormats lists the formats accepted by the output option.
+var OutputFormats = []string{"json", "yaml", "table", "csv", "tsv", "ndjson"}
+
+// formatResult renders resultList in the output format.
+// When query is given, the JMESPath expression is applied to the result list and its result is rendered instead.
+// Tabular formats only print the given columns, which are dotted paths resolved against each result.
+// Without columns, the keys of the rendered objects are used.
+func formatResult(resultList *svc.ResultList, output string, columns []string, query *jmespath.JMESPath) (string, error) {
+	var data interface{} = resultList
+	var rows []interface{}
+	if query != nil || output != "json" {
+		generic, err := toGeneric(resultList)
+		if err != nil {
+			return "", err
+		}
+		data = generic
+		rows, _ = generic.(map[string]interface{})["results"].([]interface{})
+	}
+	if query != nil {
+		result, err := query.Search(data)
+		if err != nil {
+			return "", err
+		}
+		data = result
+		if list, ok := result.([]interface{}); ok {
+			rows = list
+		} else {
+			rows = []interface{}{result}
+		}
+	}
+	return formatData(data, rows, output, columns)
+}
+
+// formatResults renders the result lists of several resources in the output format.
+// json and yaml render the list of result lists, and the other formats render the results of every list in turn.
+// When query is given, the JMESPath expression is applied to the list of result lists.
+func formatResults(resultLists []*svc.ResultList, output string, columns []string, query *jmespath.JMESPath) (string, error) {
+	var data interface{} = resultLists
+	var rows []interface{}
+	if query != nil || output != "json" {
+		generic, err := toGeneric(resultLists)
+		if err != nil {
+			return "", err
+		}
+		data = generic
+		for _, resultList := range generic.([]interface{}) {
+			results, _ := resultList.(map[string]interface{})["results"].([]interface{})
+			rows = append(rows, results...)
+		}
+	}
+	if query != nil {
+		result, err := query.Search(data)
+		if err != nil {
+			return "", err
+		}
+		data = result
+		if list, ok := result.([]interface{}); ok {
+			rows = list
+		} else {
+			rows = []interface{}{result}
+		}
+	}
+	return formatData(data, rows, output, columns)
+}
+
+// formatObject renders a single result in the output format.
+// When query is given, the JMESPath expression is applied to the result and its result is rendered instead.
+func formatObject(result interface{}, output string, columns []string, query *jmespath.JMESPath) (string, error) {
+	var data interface{} = result
+	if query != nil || output != "json" {
+		generic, err := toGeneric(result)
+		if err != nil {
+			return "", err
+		}
+		data = generic
+	}
+	if query != nil {
+		result, err := query.Search(data)
+		if err != nil {
+			return "", err
+		}
+		data = result
+	}
+	rows := []interface{}{data}
+	if list, ok := data.([]interface{}); ok && query != nil {
+		rows = list
+	}
+	return formatData(data, rows, output, columns)
+}
+
+// isTabular reports whether the output format prints columns.
+func isTabular(output string) bool {
+	return output == "table" || output == "csv" || output == "tsv"
+}
+
+// formatData renders data in the output format.
+// Formats printing one line per element, such as table and ndjson, print rows instead.
+func formatData(data interface{}, rows []interface{}, output string, columns []string) (string, error) {
+	if len(columns) == 0 {
+		columns = columnsOf(rows)
+	}
+
+	switch output {
+	case "json":
+		res, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(res), nil
+	case "yaml":
+		res, err := yaml.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(string(res), "\n"), nil
+	case "ndjson":
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			line, err := json.Marshal(row)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, string(line))
+		}
+		return strings.Join(lines, "\n"), nil
+	case "table":
+		return formatTable(rows, columns)
+	case "csv":
+		return formatDelimited(rows, columns, ',')
+	case "tsv":
+		return formatDelimited(rows, columns, '\t')
+	default:
+		return "", fmt.Errorf("output format not supported: %s", output)
+	}
+}
+
+// columnsOf returns the sorted keys of the objects in rows.
+// Rows which are not objects are printed as a whole in a single column.
+func columnsOf(rows []interface{}) []string {
+	keys := map[string]bool{}
+	for _, row := range rows {
+		object, ok := row.(map[string]interface{})
+		if !ok {
+			return []string{""}
+		}
+		for k := range object {
+			keys[k] = true
+		}
+	}
+
+	columns := make([]string, 0, len(keys))
+	for k := range keys {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func columnHeaders(columns []string) []string {
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column == "" {
+			column = "Value"
+		}
+		headers = append(headers, column)
+	}
+	return headers
+}
+
+func formatTable(rows []interface{}, columns []string) (string, error) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, strings.Join(columnHeaders(columns), "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(resolveColumns(row, columns), "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func formatDelimited(rows []interface{}, columns []string, comma rune) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = comma
+
+	if err := w.Write(columnHeaders(columns)); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		if err := w.Write(resolveColumns(row, columns)); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func resolveColumns(row interface{}, columns []string) []string {
+	values := make([]string, 0, len(columns))
+	for _, column := range columns {
+		values = append(values, formatValue(lookupPath(row, column)))
+	}
+	return values
+}
+
+// toGeneric converts v into the maps and slices produced by decoding its JSON representation.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// lookupPath resolves a dotted path such as "State.Name" against a generic value.
+// A path element matching the Key of a list of Key/Value pairs selects its Value, so that "Tags.Name" returns the Name tag.
+// Other path elements applied to a list are applied to each of its elements.
+func lookupPath(v interface{}, path string) interface{} {
+	if path == "" {
+		return v
+	}
+	key, rest, _ := strings.Cut(path, ".")
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return lookupPath(value[key], rest)
+	case []interface{}:
+		if i, err := strconv.Atoi(key); err == nil {
+			if i < 0 || i >= len(value) {
+				return nil
+			}
+			return lookupPath(value[i], rest)
+		}
+		if tagValue, ok := lookupKeyValue(value, key); ok {
+			return lookupPath(tagValue, rest)
+		}
+
+		var values []interface{}
+		for _, elem := range value {
+			if found := lookupPath(elem, path); found != nil {
+				values = append(values, found)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func lookupKeyValue(list []interface{}, key string) (interface{}, bool) {
+	for _, elem := range list {
+		pair, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		k, ok := pair["Key"].(string)
+		if !ok {
+			return nil, false
+		}
+		if k == key {
+			return pair["Value"], true
+		}
+	}
+	return nil, false
+}
+
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, elem := range value {
+			values = append(values, formatValue(elem))
+		}
+		return strings.Join(values, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+formatValue(value[k]))
+		}
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,344 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/jmespath/go-jmespath"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+func TestFormatResult(t *testing.T) {
+	resultList := &svc.ResultList{
+		Service:  "ec2",
+		Resource: "instance",
+		Results: []interface{}{
+			types.Instance{
+				InstanceId: aws.String("i-1234567890abcdef0"),
+				State: &types.InstanceState{
+					Name: types.InstanceStateNameRunning,
+				},
+				Tags: []types.Tag{
+					{Key: aws.String("Name"), Value: aws.String("web")},
+				},
+			},
+			types.Instance{
+				InstanceId: aws.String("i-0fedcba0987654321"),
+				State: &types.InstanceState{
+					Name: types.InstanceStateNameStopped,
+				},
+			},
+		},
+	}
+	columns := []string{"InstanceId", "State.Name", "Tags.Name"}
+
+	cases := []struct {
+		name     string
+		output   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:   "format as table",
+			output: "table",
+			expected: "InstanceId           State.Name  Tags.Name\n" +
+				"i-1234567890abcdef0  running     web\n" +
+				"i-0fedcba0987654321  stopped     ",
+		},
+		{
+			name:   "format as csv",
+			output: "csv",
+			expected: "InstanceId,State.Name,Tags.Name\n" +
+				"i-1234567890abcdef0,running,web\n" +
+				"i-0fedcba0987654321,stopped,",
+		},
+		{
+			name:   "format as tsv",
+			output: "tsv",
+			expected: "InstanceId\tState.Name\tTags.Name\n" +
+				"i-1234567890abcdef0\trunning\tweb\n" +
+				"i-0fedcba0987654321\tstopped\t",
+		},
+		{
+			name:    "specify undefined format",
+			output:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := formatResult(resultList, tt.output, columns, nil)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("actual = %q, want = %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatResultWithQuery(t *testing.T) {
+	resultList := &svc.ResultList{
+		Service:  "ecs",
+		Resource: "service",
+		Results: []interface{}{
+			ecstypes.Service{
+				ServiceName:  aws.String("payments-api"),
+				DesiredCount: 3,
+				RunningCount: 1,
+			},
+			ecstypes.Service{
+				ServiceName:  aws.String("orders-api"),
+				DesiredCount: 2,
+				RunningCount: 2,
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		query    string
+		output   string
+		columns  []string
+		expected string
+	}{
+		{
+			name:     "query scalar values as json",
+			query:    "results[?DesiredCount > RunningCount].ServiceName",
+			output:   "json",
+			expected: "[\n  \"payments-api\"\n]",
+		},
+		{
+			name:     "query scalar values as table",
+			query:    "results[].ServiceName",
+			output:   "table",
+			expected: "Value\npayments-api\norders-api",
+		},
+		{
+			name:     "query projected objects as csv",
+			query:    "results[].{name: ServiceName, running: RunningCount}",
+			output:   "csv",
+			expected: "name,running\npayments-api,1\norders-api,2",
+		},
+		{
+			name:     "query filtered results with columns as tsv",
+			query:    "results[?RunningCount == `2`]",
+			output:   "tsv",
+			columns:  []string{"ServiceName", "DesiredCount"},
+			expected: "ServiceName\tDesiredCount\norders-api\t2",
+		},
+		{
+			name:     "query single value as ndjson",
+			query:    "length(results)",
+			output:   "ndjson",
+			expected: "2",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			query := jmespath.MustCompile(tt.query)
+			actual, err := formatResult(resultList, tt.output, tt.columns, query)
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("actual = %q, want = %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatResults(t *testing.T) {
+	resultLists := []*svc.ResultList{
+		{
+			Service:  "ecs",
+			Resource: "cluster",
+			Results: []interface{}{
+				svc.Envelope{Service: "ecs", Resource: "cluster", ID: "production"},
+			},
+		},
+		{
+			Service:  "lambda",
+			Resource: "function",
+			Results: []interface{}{
+				svc.Envelope{Service: "lambda", Resource: "function", ID: "handler"},
+				svc.Envelope{Service: "lambda", Resource: "function", ID: "worker"},
+			},
+		},
+	}
+	columns := []string{"service", "resource", "id"}
+
+	cases := []struct {
+		name     string
+		output   string
+		query    string
+		expected string
+	}{
+		{
+			name:   "format results of every list as table",
+			output: "table",
+			expected: "service  resource  id\n" +
+				"ecs      cluster   production\n" +
+				"lambda   function  handler\n" +
+				"lambda   function  worker",
+		},
+		{
+			name:   "format results of every list as ndjson",
+			output: "ndjson",
+			query:  "[].results[].id",
+			expected: "\"production\"\n" +
+				"\"handler\"\n" +
+				"\"worker\"",
+		},
+		{
+			name:   "apply query to the list of result lists",
+			output: "json",
+			query:  "[].resource",
+			expected: "[\n" +
+				"  \"cluster\",\n" +
+				"  \"function\"\n" +
+				"]",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var query *jmespath.JMESPath
+			if tt.query != "" {
+				query = jmespath.MustCompile(tt.query)
+			}
+			actual, err := formatResults(resultLists, tt.output, columns, query)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("actual = %q, want = %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatObject(t *testing.T) {
+	result := svc.Envelope{
+		ID:     "i-1234567890abcdef0",
+		Name:   "web",
+		Region: "ap-northeast-1",
+		Tags:   map[string]string{"env": "prod"},
+	}
+
+	cases := []struct {
+		name     string
+		output   string
+		columns  []string
+		query    string
+		expected string
+	}{
+		{
+			name:     "format object as a single row",
+			output:   "table",
+			columns:  []string{"id", "name", "tags.env"},
+			expected: "id                   name  tags.env\ni-1234567890abcdef0  web   prod",
+		},
+		{
+			name:     "apply query to the object",
+			output:   "json",
+			query:    "tags.env",
+			expected: "\"prod\"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var query *jmespath.JMESPath
+			if tt.query != "" {
+				query = jmespath.MustCompile(tt.query)
+			}
+			actual, err := formatObject(result, tt.output, tt.columns, query)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("actual = %q, want = %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	value := map[string]interface{}{
+		"InstanceId": "i-1234567890abcdef0",
+		"State": map[string]interface{}{
+			"Name": "running",
+		},
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "Name", "Value": "web"},
+			map[string]interface{}{"Key": "env", "Value": "prod"},
+		},
+		"SecurityGroups": []interface{}{
+			map[string]interface{}{"GroupId": "sg-1"},
+			map[string]interface{}{"GroupId": "sg-2"},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		path     string
+		expected interface{}
+	}{
+		{
+			name:     "lookup top level field",
+			path:     "InstanceId",
+			expected: "i-1234567890abcdef0",
+		},
+		{
+			name:     "lookup nested field",
+			path:     "State.Name",
+			expected: "running",
+		},
+		{
+			name:     "lookup tag value",
+			path:     "Tags.env",
+			expected: "prod",
+		},
+		{
+			name:     "lookup list index",
+			path:     "SecurityGroups.1.GroupId",
+			expected: "sg-2",
+		},
+		{
+			name:     "lookup field of each list element",
+			path:     "SecurityGroups.GroupId",
+			expected: []interface{}{"sg-1", "sg-2"},
+		},
+		{
+			name:     "lookup undefined field",
+			path:     "State.Code",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := lookupPath(value, tt.path)
+
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+// sortKey is a dotted path resolved against each result as in columns, which the results are ordered by.
+type sortKey struct {
+	path string
+	desc bool
+}
+
+// defaultSortKeys order the results by region and then by resource ID after the keys given,
+// so that the output is the same whichever region answers first.
+// Raw results cannot be resolved against them, and are ordered by ID within each region by the services instead,
+// the regions being in the order they are searched.
+var defaultSortKeys = []sortKey{{path: "region"}, {path: "id"}}
+
+// buildSortKeys parses the sort keys, each a dotted path prefixed with '-' for descending order.
+func buildSortKeys(fields []string) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(fields))
+	for _, field := range fields {
+		key := sortKey{path: strings.TrimSpace(field)}
+		if strings.HasPrefix(key.path, "-") {
+			key.path = strings.TrimPrefix(key.path, "-")
+			key.desc = true
+		}
+		if key.path == "" {
+			return nil, fmt.Errorf("invalid sort key: '%s'", field)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortResults orders the results of each result list by the keys.
+// Results missing a key are put after the others whatever the order, and results equal in every key keep their order.
+func sortResults(resultLists []*svc.ResultList, keys []sortKey) error {
+	for _, resultList := range resultLists {
+		values := make([][]interface{}, len(resultList.Results))
+		for i, result := range resultList.Results {
+			generic, err := toGeneric(result)
+			if err != nil {
+				return err
+			}
+			values[i] = make([]interface{}, len(keys))
+			for k, key := range keys {
+				values[i][k] = lookupPath(generic, key.path)
+			}
+		}
+
+		indexes := make([]int, len(resultList.Results))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			a, b := values[indexes[i]], values[indexes[j]]
+			for k, key := range keys {
+				if c := compareSortValues(a[k], b[k], key.desc); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+
+		results := make([]interface{}, 0, len(indexes))
+		for _, i := range indexes {
+			results = append(results, resultList.Results[i])
+		}
+		resultList.Results = results
+	}
+	return nil
+}
+
+// compareSortValues compares numbers, strings and booleans of the same type by their values,
+// and other values by their formatted strings.
+func compareSortValues(a, b interface{}, desc bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	var c int
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				c = -1
+			case x > y:
+				c = 1
+			}
+			break
+		}
+		c = strings.Compare(formatValue(a), formatValue(b))
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case !x && y:
+				c = -1
+			case x && !y:
+				c = 1
+			}
+			break
+		}
+		c = strings.Compare(formatValue(a), formatValue(b))
+	default:
+		c = strings.Compare(formatValue(a), formatValue(b))
+	}
+
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// limitResults keeps at most limit results in each result list. Zero means no limit.
+func limitResults(resultLists []*svc.ResultList, limit int) {
+	if limit <= 0 {
+		return
+	}
+	for _, resultList := range resultLists {
+		if len(resultList.Results) > limit {
+			resultList.Results = resultList.Results[:limit]
+		}
+	}
+}
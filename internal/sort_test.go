@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+func TestBuildSortKeys(t *testing.T) {
+	cases := []struct {
+		name      string
+		fields    []string
+		expected  []sortKey
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "ascending and descending keys",
+			fields:   []string{"name", "-createdAt"},
+			expected: []sortKey{{path: "name"}, {path: "createdAt", desc: true}},
+		},
+		{
+			name:      "empty key",
+			fields:    []string{"name", "-"},
+			wantErr:   true,
+			expectErr: "invalid sort key: '-'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := buildSortKeys(tt.fields)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	older := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	envelopes := []interface{}{
+		svc.Envelope{ID: "worker", Region: "us-east-1", CreatedAt: &older},
+		svc.Envelope{ID: "handler", Region: "us-east-1"},
+		svc.Envelope{ID: "worker", Region: "ap-northeast-1", CreatedAt: &newer},
+		svc.Envelope{ID: "api", Region: "us-east-1", CreatedAt: &newer},
+	}
+	functions := []interface{}{
+		lambdatypes.FunctionConfiguration{FunctionName: aws.String("small"), MemorySize: aws.Int32(128)},
+		lambdatypes.FunctionConfiguration{FunctionName: aws.String("large"), MemorySize: aws.Int32(2048)},
+		lambdatypes.FunctionConfiguration{FunctionName: aws.String("medium"), MemorySize: aws.Int32(512)},
+	}
+
+	cases := []struct {
+		name     string
+		results  []interface{}
+		fields   []string
+		path     string
+		expected []interface{}
+	}{
+		{
+			name:     "order by region and id by default",
+			results:  envelopes,
+			path:     "id",
+			expected: []interface{}{"worker", "api", "handler", "worker"},
+		},
+		{
+			name:     "order by descending key before the default keys",
+			results:  envelopes,
+			fields:   []string{"-createdAt"},
+			path:     "region",
+			expected: []interface{}{"ap-northeast-1", "us-east-1", "us-east-1", "us-east-1"},
+		},
+		{
+			name:     "put results missing the key last",
+			results:  envelopes,
+			fields:   []string{"createdAt"},
+			path:     "id",
+			expected: []interface{}{"worker", "worker", "api", "handler"},
+		},
+		{
+			name:     "order numbers by their values",
+			results:  functions,
+			fields:   []string{"-MemorySize"},
+			path:     "FunctionName",
+			expected: []interface{}{"large", "medium", "small"},
+		},
+		{
+			name:     "keep the order of raw results without keys",
+			results:  functions,
+			path:     "FunctionName",
+			expected: []interface{}{"small", "large", "medium"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := buildSortKeys(tt.fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resultLists := []*svc.ResultList{
+				{Results: append([]interface{}{}, tt.results...)},
+			}
+			if err := sortResults(resultLists, append(keys, defaultSortKeys...)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actual := make([]interface{}, 0, len(tt.expected))
+			for _, result := range resultLists[0].Results {
+				generic, _ := toGeneric(result)
+				actual = append(actual, lookupPath(generic, tt.path))
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLimitResults(t *testing.T) {
+	resultLists := []*svc.ResultList{
+		{Results: []interface{}{"a", "b", "c"}},
+		{Results: []interface{}{"d"}},
+	}
+
+	limitResults(resultLists, 2)
+
+	if !reflect.DeepEqual(resultLists[0].Results, []interface{}{"a", "b"}) {
+		t.Errorf("expected the first 2 results, but got %v", resultLists[0].Results)
+	}
+	if !reflect.DeepEqual(resultLists[1].Results, []interface{}{"d"}) {
+		t.Errorf("expected the results under the limit to be kept, but got %v", resultLists[1].Results)
+	}
+}
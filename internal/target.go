@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+// target is a resource of a service to search.
+type target struct {
+	service  string
+	resource string
+}
+
+// buildServices resolves the service option into the names of the services to search.
+// The option is a comma separated list of service names, aliases and glob patterns such as "ec*".
+// Patterns are matched against the service names.
+func buildServices(service string) ([]string, error) {
+	var services []string
+	for _, s := range strings.Split(service, ",") {
+		if !isPattern(s) {
+			def, err := svc.LookupService(s)
+			if err != nil {
+				return nil, err
+			}
+			services = appendUnique(services, def.Name)
+			continue
+		}
+
+		matched := false
+		for _, def := range svc.Services() {
+			ok, err := path.Match(s, def.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid service pattern: '%s'", s)
+			}
+			if ok {
+				services = appendUnique(services, def.Name)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("service pattern matches no service: '%s'", s)
+		}
+	}
+
+	return services, nil
+}
+
+// buildTargets resolves the resource option into the resources of services to search.
+// The option is a comma separated list of resource names and glob patterns such as "*".
+// A resource name must be supported by at least one of the services, and a pattern must match at least one resource.
+func buildTargets(services []string, resource string) ([]target, error) {
+	var targets []target
+	for _, r := range strings.Split(resource, ",") {
+		matched := false
+		for _, service := range services {
+			def, err := svc.LookupService(service)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range def.ResourceNames() {
+				ok, err := path.Match(r, name)
+				if err != nil {
+					return nil, fmt.Errorf("invalid resource pattern: '%s'", r)
+				}
+				if !ok {
+					continue
+				}
+				matched = true
+				if t := (target{service, name}); !slices.Contains(targets, t) {
+					targets = append(targets, t)
+				}
+			}
+		}
+		if matched {
+			continue
+		}
+		if isPattern(r) {
+			return nil, fmt.Errorf("resource pattern matches no resource: '%s'", r)
+		}
+		return nil, fmt.Errorf("resource '%s' not supported in service '%s'", r, strings.Join(services, ","))
+	}
+
+	// search in the order of the services
+	sorted := make([]target, 0, len(targets))
+	for _, service := range services {
+		for _, t := range targets {
+			if t.service == service {
+				sorted = append(sorted, t)
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// isPattern reports whether s is a glob pattern rather than a name.
+func isPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+func appendUnique(list []string, s string) []string {
+	if slices.Contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildServices(t *testing.T) {
+	cases := []struct {
+		name      string
+		service   string
+		expected  []string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "single service",
+			service:  "ecs",
+			expected: []string{"ecs"},
+		},
+		{
+			name:     "list of services and aliases",
+			service:  "lambda,configservice,ecs",
+			expected: []string{"lambda", "config", "ecs"},
+		},
+		{
+			name:     "pattern",
+			service:  "ec*",
+			expected: []string{"ec2", "ecr", "ecs"},
+		},
+		{
+			name:     "duplicated service",
+			service:  "ecs,ec*",
+			expected: []string{"ecs", "ec2", "ecr"},
+		},
+		{
+			name:      "undefined service",
+			service:   "ecs,custom",
+			wantErr:   true,
+			expectErr: "service not supported: custom",
+		},
+		{
+			name:      "pattern matching no service",
+			service:   "xyz*",
+			wantErr:   true,
+			expectErr: "service pattern matches no service: 'xyz*'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := buildServices(tt.service)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildTargets(t *testing.T) {
+	cases := []struct {
+		name      string
+		services  []string
+		resource  string
+		expected  []target
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "single resource",
+			services: []string{"ecs"},
+			resource: "cluster",
+			expected: []target{{"ecs", "cluster"}},
+		},
+		{
+			name:     "every resource of services",
+			services: []string{"lambda", "ecr"},
+			resource: "*",
+			expected: []target{{"lambda", "function"}, {"ecr", "repository"}},
+		},
+		{
+			name:     "list of resources in the order of services",
+			services: []string{"ecs", "ecr"},
+			resource: "repository,cluster,task*",
+			expected: []target{{"ecs", "cluster"}, {"ecs", "task"}, {"ecs", "task-definition"}, {"ecr", "repository"}},
+		},
+		{
+			name:     "resource supported in some of services",
+			services: []string{"ecs", "lambda"},
+			resource: "function",
+			expected: []target{{"lambda", "function"}},
+		},
+		{
+			name:      "resource supported in none of services",
+			services:  []string{"ecs", "lambda"},
+			resource:  "bucket",
+			wantErr:   true,
+			expectErr: "resource 'bucket' not supported in service 'ecs,lambda'",
+		},
+		{
+			name:      "pattern matching no resource",
+			services:  []string{"ecs"},
+			resource:  "bucket*",
+			wantErr:   true,
+			expectErr: "resource pattern matches no resource: 'bucket*'",
+		},
+		{
+			name:      "empty resource",
+			services:  []string{"ecs"},
+			resource:  "",
+			wantErr:   true,
+			expectErr: "resource '' not supported in service 'ecs'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := buildTargets(tt.services, tt.resource)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,567 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+// whereExpr is a parsed where expression, which selects the results it evaluates to true for.
+//
+// An expression compares dotted paths resolved against each result as in columns, such as raw.RunningCount,
+// with other paths or with literals: 'strings' or "strings", numbers, true, false and null.
+// Comparisons use ==, !=, <, <=, > and >=, and =~ and !~ match a regular expression given as a string.
+// contains(a, b), startsWith(a, b) and endsWith(a, b) test strings, and contains also tests lists and map keys.
+// any(path, expr) is true when expr is true for an element of the list at path, with paths in expr resolved
+// against the element and @ referring to the element itself.
+// Expressions are combined with and, or, not (or &&, ||, !) and parentheses,
+// and a path alone is true when its value is neither null, false, zero nor empty.
+type whereExpr struct {
+	node whereNode
+}
+
+// parseWhere parses a where expression.
+func parseWhere(expr string) (*whereExpr, error) {
+	tokens, err := tokenizeWhere(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &whereParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", t.text, t.pos)
+	}
+	return &whereExpr{node: node}, nil
+}
+
+// match reports whether the expression is true for the result.
+func (w *whereExpr) match(result interface{}) bool {
+	generic, err := toGeneric(result)
+	if err != nil {
+		return false
+	}
+	return w.node.eval(generic)
+}
+
+// filter removes the results the expression is false for from the result lists.
+// Unlike filterResults, result lists left empty are kept, so that the output has the same shape.
+func (w *whereExpr) filter(resultLists []*svc.ResultList) {
+	for _, resultList := range resultLists {
+		results := []interface{}{}
+		for _, result := range resultList.Results {
+			if w.match(result) {
+				results = append(results, result)
+			}
+		}
+		resultList.Results = results
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos is the position of the token in the expression, starting from 1.
+	pos int
+}
+
+// whereOperators are sorted so that two character operators are matched before their prefixes.
+var whereOperators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "!"}
+
+func tokenizeWhere(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i + 1})
+			i++
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(expr) && expr[j] != c; j++ {
+				if expr[j] == '\\' && j+1 < len(expr) {
+					j++
+				}
+				sb.WriteByte(expr[j])
+			}
+			if j == len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i+1)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: i + 1})
+			i = j + 1
+		case isDigit(c) || (c == '-' && i+1 < len(expr) && isDigit(expr[i+1])):
+			j := i + 1
+			for j < len(expr) && (isDigit(expr[j]) || expr[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:j], pos: i + 1})
+			i = j
+		case c == '@':
+			tokens = append(tokens, token{kind: tokenIdent, text: "@", pos: i + 1})
+			i++
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(expr) && isIdentPart(expr[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[i:j], pos: i + 1})
+			i = j
+		default:
+			op := ""
+			for _, o := range whereOperators {
+				if strings.HasPrefix(expr[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected '%c' at position %d", c, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i + 1})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", pos: len(expr) + 1}), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// isIdentPart allows the characters of tag keys such as aws:cloudformation:stack-name in paths.
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '-' || c == ':' || c == '/'
+}
+
+type whereParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *whereParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *whereParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *whereParser) expect(kind tokenKind, text string) error {
+	if t := p.next(); t.kind != kind {
+		return fmt.Errorf("expected '%s' at position %d, got '%s'", text, t.pos, t.text)
+	}
+	return nil
+}
+
+// isKeyword reports whether the token is one of the keywords, which are case insensitive.
+func isKeyword(t token, keywords ...string) bool {
+	if t.kind != tokenIdent {
+		return false
+	}
+	for _, k := range keywords {
+		if strings.EqualFold(t.text, k) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *whereParser) parseOr() (whereNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); isKeyword(t, "or") || t.kind == tokenOperator && t.text == "||"; t = p.peek() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (whereNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); isKeyword(t, "and") || t.kind == tokenOperator && t.text == "&&"; t = p.peek() {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseNot() (whereNode, error) {
+	if t := p.peek(); isKeyword(t, "not") || t.kind == tokenOperator && t.text == "!" {
+		p.next()
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *whereParser) parsePrimary() (whereNode, error) {
+	t := p.peek()
+	if t.kind == tokenLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+	if t.kind == tokenIdent && p.tokens[p.pos+1].kind == tokenLParen {
+		return p.parseFunction()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	if op.kind != tokenOperator {
+		return truthNode{operand: left}, nil
+	}
+	switch op.text {
+	case "=~", "!~":
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, fmt.Errorf("expected a regular expression string at position %d, got '%s'", pattern.pos, pattern.text)
+		}
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at position %d: %w", pattern.pos, err)
+		}
+		return regexNode{operand: left, re: re, negate: op.text == "!~"}, nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: op.text, left: left, right: right}, nil
+	default:
+		return truthNode{operand: left}, nil
+	}
+}
+
+func (p *whereParser) parseFunction() (whereNode, error) {
+	name := p.next()
+	p.next()
+
+	switch name.text {
+	case "any":
+		list, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := list.(pathOperand); !ok {
+			return nil, fmt.Errorf("any takes a path to a list at position %d", name.pos)
+		}
+		if err := p.expect(tokenComma, ","); err != nil {
+			return nil, err
+		}
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return anyNode{list: list, cond: cond}, nil
+	case "contains", "startsWith", "endsWith":
+		left, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenComma, ","); err != nil {
+			return nil, err
+		}
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return functionNode{name: name.text, left: left, right: right}, nil
+	default:
+		return nil, fmt.Errorf("unknown function '%s' at position %d", name.text, name.pos)
+	}
+}
+
+func (p *whereParser) parseOperand() (operand, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return literalOperand{literal: t.text}, nil
+	case t.kind == tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", t.text, t.pos)
+		}
+		return literalOperand{literal: n}, nil
+	case isKeyword(t, "true"):
+		return literalOperand{literal: true}, nil
+	case isKeyword(t, "false"):
+		return literalOperand{literal: false}, nil
+	case isKeyword(t, "null"):
+		return literalOperand{literal: nil}, nil
+	case t.kind == tokenIdent && t.text == "@":
+		return pathOperand{path: ""}, nil
+	case t.kind == tokenIdent && !isKeyword(t, "and", "or", "not"):
+		return pathOperand{path: t.text}, nil
+	default:
+		return nil, fmt.Errorf("expected a path or a value at position %d, got '%s'", t.pos, t.text)
+	}
+}
+
+// whereNode is a boolean expression evaluated against a generic value.
+type whereNode interface {
+	eval(v interface{}) bool
+}
+
+// operand is resolved into a generic value.
+type operand interface {
+	value(v interface{}) interface{}
+}
+
+type pathOperand struct {
+	path string
+}
+
+func (o pathOperand) value(v interface{}) interface{} {
+	return lookupPath(v, o.path)
+}
+
+type literalOperand struct {
+	literal interface{}
+}
+
+func (o literalOperand) value(interface{}) interface{} {
+	return o.literal
+}
+
+type andNode struct {
+	left, right whereNode
+}
+
+func (n andNode) eval(v interface{}) bool {
+	return n.left.eval(v) && n.right.eval(v)
+}
+
+type orNode struct {
+	left, right whereNode
+}
+
+func (n orNode) eval(v interface{}) bool {
+	return n.left.eval(v) || n.right.eval(v)
+}
+
+type notNode struct {
+	node whereNode
+}
+
+func (n notNode) eval(v interface{}) bool {
+	return !n.node.eval(v)
+}
+
+type truthNode struct {
+	operand operand
+}
+
+func (n truthNode) eval(v interface{}) bool {
+	switch value := n.operand.value(v).(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	case string:
+		return value != ""
+	case []interface{}:
+		return len(value) > 0
+	case map[string]interface{}:
+		return len(value) > 0
+	default:
+		return true
+	}
+}
+
+// compareNode compares numbers and strings in their order, and any values for equality.
+// Values of different types are never ordered.
+type compareNode struct {
+	op          string
+	left, right operand
+}
+
+func (n compareNode) eval(v interface{}) bool {
+	a, b := n.left.value(v), n.right.value(v)
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(a, b)
+	case "!=":
+		return !reflect.DeepEqual(a, b)
+	}
+
+	var c int
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case x < y:
+			c = -1
+		case x > y:
+			c = 1
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(x, y)
+	default:
+		return false
+	}
+
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+type regexNode struct {
+	operand operand
+	re      *regexp.Regexp
+	negate  bool
+}
+
+func (n regexNode) eval(v interface{}) bool {
+	s, ok := n.operand.value(v).(string)
+	if !ok {
+		return n.negate
+	}
+	return n.re.MatchString(s) != n.negate
+}
+
+type functionNode struct {
+	name        string
+	left, right operand
+}
+
+func (n functionNode) eval(v interface{}) bool {
+	a, b := n.left.value(v), n.right.value(v)
+	if n.name == "contains" {
+		switch x := a.(type) {
+		case []interface{}:
+			for _, elem := range x {
+				if reflect.DeepEqual(elem, b) {
+					return true
+				}
+			}
+			return false
+		case map[string]interface{}:
+			key, ok := b.(string)
+			if !ok {
+				return false
+			}
+			_, found := x[key]
+			return found
+		}
+	}
+
+	s, ok := a.(string)
+	if !ok {
+		return false
+	}
+	sub, ok := b.(string)
+	if !ok {
+		return false
+	}
+	switch n.name {
+	case "contains":
+		return strings.Contains(s, sub)
+	case "startsWith":
+		return strings.HasPrefix(s, sub)
+	default:
+		return strings.HasSuffix(s, sub)
+	}
+}
+
+type anyNode struct {
+	list operand
+	cond whereNode
+}
+
+func (n anyNode) eval(v interface{}) bool {
+	list, ok := n.list.value(v).([]interface{})
+	if !ok {
+		return false
+	}
+	for _, elem := range list {
+		if n.cond.eval(elem) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	svc "github.com/thaim/awsresq/service"
+)
+
+func TestParseWhere(t *testing.T) {
+	cases := []struct {
+		name      string
+		expr      string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name: "comparison of paths",
+			expr: "raw.RunningCount < raw.DesiredCount",
+		},
+		{
+			name: "boolean operators with parentheses",
+			expr: "not (region == 'us-east-1' || region == 'us-west-2') && tags.env != null",
+		},
+		{
+			name: "functions and regular expression",
+			expr: "contains(name, 'api') and startsWith(raw.Runtime, 'python3') or id =~ '^i-[0-9a-f]+$'",
+		},
+		{
+			name: "any of list elements",
+			expr: "any(raw.SecurityGroups, GroupName == 'default')",
+		},
+		{
+			name:      "missing operand",
+			expr:      "raw.RunningCount <",
+			wantErr:   true,
+			expectErr: "expected a path or a value at position 19, got 'end of expression'",
+		},
+		{
+			name:      "unbalanced parentheses",
+			expr:      "(region == 'us-east-1'",
+			wantErr:   true,
+			expectErr: "expected ')' at position 23",
+		},
+		{
+			name:      "unterminated string",
+			expr:      "name == 'web",
+			wantErr:   true,
+			expectErr: "unterminated string at position 9",
+		},
+		{
+			name:      "unknown function",
+			expr:      "endsWithIgnoreCase(name, 'api')",
+			wantErr:   true,
+			expectErr: "unknown function 'endsWithIgnoreCase' at position 1",
+		},
+		{
+			name:      "regular expression not given as string",
+			expr:      "name =~ web",
+			wantErr:   true,
+			expectErr: "expected a regular expression string at position 9",
+		},
+		{
+			name:      "invalid regular expression",
+			expr:      "name =~ '('",
+			wantErr:   true,
+			expectErr: "invalid regular expression at position 9",
+		},
+		{
+			name:      "single equal sign",
+			expr:      "name = 'web'",
+			wantErr:   true,
+			expectErr: "unexpected '=' at position 6",
+		},
+		{
+			name:      "trailing tokens",
+			expr:      "name == 'web' 'api'",
+			wantErr:   true,
+			expectErr: "unexpected 'api' at position 15",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseWhere(tt.expr)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWhereMatch(t *testing.T) {
+	service := svc.Envelope{
+		ID:     "payments-api",
+		Name:   "payments-api",
+		Region: "ap-northeast-1",
+		Tags:   map[string]string{"env": "prod", "aws:cloudformation:stack-name": "payments"},
+		Raw: ecstypes.Service{
+			ServiceName:  aws.String("payments-api"),
+			DesiredCount: 3,
+			RunningCount: 1,
+			LaunchType:   ecstypes.LaunchTypeFargate,
+			Deployments: []ecstypes.Deployment{
+				{Status: aws.String("PRIMARY"), RolloutState: ecstypes.DeploymentRolloutStateInProgress},
+				{Status: aws.String("ACTIVE"), RolloutState: ecstypes.DeploymentRolloutStateCompleted},
+			},
+		},
+	}
+	function := lambdatypes.FunctionConfiguration{
+		FunctionName:  aws.String("report"),
+		MemorySize:    aws.Int32(2048),
+		Runtime:       lambdatypes.RuntimePython37,
+		Architectures: []lambdatypes.Architecture{lambdatypes.ArchitectureArm64},
+	}
+
+	cases := []struct {
+		name     string
+		expr     string
+		result   interface{}
+		expected bool
+	}{
+		{
+			name:     "compare numbers of paths",
+			expr:     "raw.RunningCount < raw.DesiredCount",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "compare number with literal",
+			expr:     "raw.DesiredCount >= 4",
+			result:   service,
+			expected: false,
+		},
+		{
+			name:     "compare strings for equality",
+			expr:     "raw.LaunchType == 'FARGATE' and region != \"us-east-1\"",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "compare undefined path with null",
+			expr:     "raw.PlatformVersion == null",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "never order values of different types",
+			expr:     "raw.DesiredCount > '1' or raw.DesiredCount <= '1'",
+			result:   service,
+			expected: false,
+		},
+		{
+			name:     "combine conditions with or and not",
+			expr:     "not raw.RunningCount == 1 || tags.env == 'prod'",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "and binds tighter than or",
+			expr:     "tags.env == 'dev' and raw.RunningCount == 1 or raw.DesiredCount == 3",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "group conditions with parentheses",
+			expr:     "tags.env == 'dev' and (raw.RunningCount == 1 or raw.DesiredCount == 3)",
+			result:   service,
+			expected: false,
+		},
+		{
+			name:     "match regular expression",
+			expr:     "name =~ '^payments-' && id !~ 'worker$'",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "contains substring",
+			expr:     "contains(name, 'api')",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "contains map key",
+			expr:     "contains(tags, 'aws:cloudformation:stack-name')",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "lookup tag key including colons",
+			expr:     "tags.aws:cloudformation:stack-name == 'payments'",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "any element matching",
+			expr:     "any(raw.Deployments, Status == 'PRIMARY' and RolloutState == 'IN_PROGRESS')",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "no element matching",
+			expr:     "any(raw.Deployments, RolloutState == 'FAILED')",
+			result:   service,
+			expected: false,
+		},
+		{
+			name:     "path alone is truthy",
+			expr:     "raw.Deployments && !raw.EnableExecuteCommand",
+			result:   service,
+			expected: true,
+		},
+		{
+			name:     "raw result with number and prefix",
+			expr:     "MemorySize > 1024 and startsWith(Runtime, 'python3.7')",
+			result:   function,
+			expected: true,
+		},
+		{
+			name:     "contains list element",
+			expr:     "contains(Architectures, 'arm64') and endsWith(FunctionName, 'port')",
+			result:   function,
+			expected: true,
+		},
+		{
+			name:     "any element itself",
+			expr:     "any(Architectures, @ == 'x86_64')",
+			result:   function,
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			where, err := parseWhere(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actual := where.match(tt.result)
+			if actual != tt.expected {
+				t.Errorf("actual = %v, want = %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWhereFilter(t *testing.T) {
+	resultLists := []*svc.ResultList{
+		{
+			Service:  "lambda",
+			Resource: "function",
+			Results: []interface{}{
+				svc.Envelope{ID: "handler", Region: "ap-northeast-1"},
+				svc.Envelope{ID: "worker", Region: "us-east-1"},
+			},
+		},
+		{
+			Service:  "ecs",
+			Resource: "cluster",
+			Results: []interface{}{
+				svc.Envelope{ID: "production", Region: "us-east-1"},
+			},
+		},
+	}
+
+	where, err := parseWhere("region == 'ap-northeast-1'")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	where.filter(resultLists)
+
+	if len(resultLists) != 2 {
+		t.Fatalf("expected result lists left empty to be kept, but got %d lists", len(resultLists))
+	}
+	if len(resultLists[0].Results) != 1 || resultLists[0].Results[0].(svc.Envelope).ID != "handler" {
+		t.Errorf("expected handler only, but got %v", resultLists[0].Results)
+	}
+	if len(resultLists[1].Results) != 0 {
+		t.Errorf("expected no results, but got %v", resultLists[1].Results)
+	}
+}
@@ -1,20 +1,60 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 
 	awsresq "github.com/thaim/awsresq/internal"
+	svc "github.com/thaim/awsresq/service"
+)
+
+const (
+	exitCodeError   = 1
+	exitCodePartial = 2
 )
 
 var (
-	version  = "main"
-	region   string
-	service  string
-	resource string
+	version        = "main"
+	region         string
+	excludeRegions string
+	partition      string
+	service        string
+	resource       string
+
+	timeout        time.Duration
+	maxRetries     int
+	retryMode      string
+	maxConcurrency int
+	output         string
+	columns        string
+	query          string
+	where          string
+	sortBy         string
+	limit          int
+	tags           cli.StringSlice
+	excludeTags    cli.StringSlice
+	filters        []string
+
+	profile     string
+	roleArn     string
+	externalID  string
+	mfaSerial   string
+	sessionName string
+
+	accounts    string
+	accountRole string
+
+	raw bool
+
+	regex bool
 )
 
 func main() {
@@ -24,53 +64,383 @@ func main() {
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "region",
-				Usage:       "region name",
+				Usage:       "comma separated region names or glob patterns such as 'eu-*' ('all' for every enabled region)",
 				Destination: &region,
 			},
+			&cli.StringFlag{
+				Name:        "exclude-region",
+				Usage:       "comma separated region names or glob patterns excluded from the search",
+				Destination: &excludeRegions,
+			},
+			&cli.StringFlag{
+				Name:        "partition",
+				Usage:       "AWS partition (aws, aws-us-gov, aws-cn), defaults to the partition of the configured region",
+				Destination: &partition,
+			},
 			&cli.StringFlag{
 				Name:        "service",
-				Usage:       "service name",
+				Usage:       "comma separated service names or glob patterns (" + strings.Join(serviceNames(), ", ") + ")",
 				Destination: &service,
-				Required:    true,
 			},
 			&cli.StringFlag{
 				Name:        "resource",
-				Usage:       "resource name",
+				Usage:       "comma separated resource names or glob patterns such as '*' (see list-resources for the resources of each service)",
 				Destination: &resource,
 			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "timeout for the whole search",
+				Value:       30 * time.Second,
+				Destination: &timeout,
+			},
+			&cli.IntFlag{
+				Name:        "max-retries",
+				Usage:       "maximum number of retries for each API call",
+				Value:       2,
+				Destination: &maxRetries,
+			},
+			&cli.StringFlag{
+				Name:        "retry-mode",
+				Usage:       "retry mode (standard, adaptive)",
+				Value:       "standard",
+				Destination: &retryMode,
+			},
+			&cli.IntFlag{
+				Name:        "max-concurrency",
+				Usage:       "maximum number of regions queried concurrently (0 for unlimited)",
+				Value:       8,
+				Destination: &maxConcurrency,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "output format (" + strings.Join(awsresq.OutputFormats, ", ") + ")",
+				Value:       "json",
+				Destination: &output,
+			},
+			&cli.StringFlag{
+				Name:        "columns",
+				Usage:       "comma separated fields printed in table, csv and tsv output (e.g. id,tags.Name,raw.State.Name)",
+				Destination: &columns,
+			},
+			&cli.StringFlag{
+				Name:        "query",
+				Usage:       "JMESPath query applied to the result (e.g. \"results[?raw.State.Name=='running'].id\")",
+				Destination: &query,
+			},
+			&cli.StringFlag{
+				Name:        "where",
+				Usage:       "expression selecting the results to print (e.g. \"raw.RunningCount < raw.DesiredCount\", \"startsWith(raw.Runtime, 'python3') and raw.MemorySize > 1024\")",
+				Destination: &where,
+			},
+			&cli.StringFlag{
+				Name:        "sort-by",
+				Usage:       "comma separated fields the results are ordered by, each prefixed with '-' for descending order (e.g. -createdAt,name), before region and id",
+				Destination: &sortBy,
+			},
+			&cli.IntFlag{
+				Name:        "limit",
+				Usage:       "maximum number of results printed for each resource (0 for unlimited)",
+				Destination: &limit,
+			},
+			&cli.BoolFlag{
+				Name:        "raw",
+				Usage:       "print the results as returned by the AWS APIs instead of wrapping them in envelopes",
+				Destination: &raw,
+			},
+			&cli.StringSliceFlag{
+				Name:        "tag",
+				Usage:       "select resources having the tag in the form of key=value or key (repeatable)",
+				Destination: &tags,
+			},
+			&cli.StringSliceFlag{
+				Name:        "tag-not",
+				Usage:       "select resources not having the tag in the form of key=value or key (repeatable)",
+				Destination: &excludeTags,
+			},
+			&cli.StringSliceFlag{
+				Name:  "filter",
+				Usage: "select resources by their attributes in the form of Name=name,Values=value1,value2 (repeatable, e.g. Name=instance-state-name,Values=running)",
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Usage:       "shared config profile name",
+				Destination: &profile,
+			},
+			&cli.StringFlag{
+				Name:        "role-arn",
+				Usage:       "ARN of the role assumed to search resources",
+				Destination: &roleArn,
+			},
+			&cli.StringFlag{
+				Name:        "external-id",
+				Usage:       "external ID passed when assuming the role",
+				Destination: &externalID,
+			},
+			&cli.StringFlag{
+				Name:        "mfa-serial",
+				Usage:       "serial number of the MFA device used when assuming the role (token code is read from stdin)",
+				Destination: &mfaSerial,
+			},
+			&cli.StringFlag{
+				Name:        "session-name",
+				Usage:       "session name used when assuming the role",
+				Destination: &sessionName,
+			},
+			&cli.StringFlag{
+				Name:        "accounts",
+				Usage:       "search multiple accounts: 'organization', comma separated account IDs, or file://path listing account IDs",
+				Destination: &accounts,
+			},
+			&cli.StringFlag{
+				Name:        "account-role",
+				Usage:       "name of the role assumed in each account searched with --accounts",
+				Value:       "OrganizationAccountAccessRole",
+				Destination: &accountRole,
+			},
+		},
+		// values of slice flags with a destination are split at commas regardless of DisableSliceFlagSeparator,
+		// which breaks the values of filters
+		Before: func(ctx *cli.Context) error {
+			filters = ctx.StringSlice("filter")
+			return nil
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "list-services",
+				Usage: "list the supported services",
+				Action: func(ctx *cli.Context) error {
+					res, err := awsresq.ListServices(listOutput(ctx))
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(os.Stdout, res)
+					return nil
+				},
+			},
+			{
+				Name:      "list-resources",
+				Usage:     "list the supported resources of the service, or of every service",
+				ArgsUsage: "[service]",
+				Action: func(ctx *cli.Context) error {
+					name := service
+					if ctx.Args().Present() {
+						name = ctx.Args().First()
+					}
+					res, err := awsresq.ListResources(name, listOutput(ctx))
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(os.Stdout, res)
+					return nil
+				},
+			},
+			{
+				Name:      "find",
+				Usage:     "find resources whose ID, name, ARN or tag values match the term, in every service unless --service is given",
+				ArgsUsage: "<term>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "regex",
+						Usage:       "match the term as a regular expression instead of a case insensitive substring",
+						Destination: &regex,
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						_ = cli.ShowCommandHelp(ctx, "find")
+						return errors.New("find requires a single search term")
+					}
+					name := service
+					if name == "" {
+						name = "*"
+					}
+					return printResult(newClient(region, name).Find(resource, ctx.Args().First(), regex))
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "get the resource identified by the ARN",
+				ArgsUsage: "<arn>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						_ = cli.ShowCommandHelp(ctx, "get")
+						return errors.New("get requires a single ARN")
+					}
+					resourceARN, err := svc.ParseResourceARN(ctx.Args().First())
+					if err != nil {
+						return err
+					}
+					// the ARN is got in its own partition, unless another one is given explicitly,
+					// in which case the client reports the mismatch
+					if partition == "" {
+						partition = resourceARN.Partition
+					}
+					// ARNs of global resources have no region, which are queried in the default region of the partition.
+					// Other resources without region, such as DynamoDB global tables, are searched in every region
+					// unless --region narrows them.
+					getRegion := resourceARN.Region
+					if getRegion == "" {
+						def, err := svc.LookupService(resourceARN.Service)
+						if err != nil {
+							return err
+						}
+						getRegion = region
+						if def.Global {
+							p, err := svc.LookupPartition(resourceARN.Partition)
+							if err != nil {
+								return err
+							}
+							getRegion = p.DefaultRegion
+						}
+					}
+					return printResult(newClient(getRegion, resourceARN.Service).Get(resourceARN))
+				},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
-			client, err := awsresq.NewAwsresqClient(region, service)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "initialized failed:%v\n", err)
-				os.Exit(1)
+			if service == "" {
+				_ = cli.ShowAppHelp(ctx)
+				return errors.New("Required flag \"service\" not set")
 			}
-
-			validate := client.Validate(resource)
-			if !validate {
-				fmt.Fprintf(os.Stderr, "resource '%s' not supported in service '%s'\n", resource, service)
-				os.Exit(1)
+			client := newClient(region, service)
+			if err := client.Validate(resource); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(exitCodeError)
 			}
 
-			res, err := client.Search(service, resource)
-			if err == nil {
-				fmt.Fprint(os.Stdout, res+"\n")
+			return printResult(client.Search(resource))
+		},
+		EnableBashCompletion: true,
+		BashComplete: func(ctx *cli.Context) {
+			if !completeFlagValue(os.Stdout, os.Args) {
+				cli.DefaultAppComplete(ctx)
 			}
-			return err
 		},
-		HideHelpCommand: true,
-		Version:         getVersion(),
+		HideHelpCommand:           true,
+		DisableSliceFlagSeparator: true,
+		Version:                   getVersion(),
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(exitCodeError)
 	}
 
 	os.Exit(0)
 }
 
+// newClient builds the client searching the services in the regions with the global options,
+// exiting when it cannot be built.
+func newClient(region, service string) *awsresq.AwsresqClient {
+	opt := awsresq.ClientOption{
+		Timeout:        timeout,
+		MaxRetries:     maxRetries,
+		RetryMode:      retryMode,
+		MaxConcurrency: maxConcurrency,
+		Output:         output,
+		Query:          query,
+		Where:          where,
+		Limit:          limit,
+		Tags:           tags.Value(),
+		ExcludeTags:    excludeTags.Value(),
+		Filters:        filters,
+		Credential: awsresq.CredentialOption{
+			Profile:     profile,
+			RoleArn:     roleArn,
+			ExternalID:  externalID,
+			MFASerial:   mfaSerial,
+			SessionName: sessionName,
+		},
+		Partition:   partition,
+		Accounts:    accounts,
+		AccountRole: accountRole,
+		Raw:         raw,
+	}
+	if excludeRegions != "" {
+		opt.ExcludeRegions = strings.Split(excludeRegions, ",")
+	}
+	if columns != "" {
+		opt.Columns = strings.Split(columns, ",")
+	}
+	if sortBy != "" {
+		opt.SortBy = strings.Split(sortBy, ",")
+	}
+	client, err := awsresq.NewAwsresqClient(region, service, opt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "initialized failed:%v\n", err)
+		os.Exit(exitCodeError)
+	}
+	return client
+}
+
+// printResult prints the result of a search, exiting with exitCodePartial when some regions failed.
+func printResult(res string, err error) error {
+	if res != "" {
+		fmt.Fprint(os.Stdout, res+"\n")
+	}
+	if errors.Is(err, awsresq.ErrPartialResult) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodePartial)
+	}
+	return err
+}
+
+// listOutput returns the output format of the list commands, which are printed as a table unless specified.
+func listOutput(ctx *cli.Context) string {
+	if ctx.IsSet("output") {
+		return output
+	}
+	return "table"
+}
+
+func serviceNames() []string {
+	var names []string
+	for _, def := range svc.Services() {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// completeFlagValue prints the candidate values of --service and --resource when the shell completes them.
+// args are the command line arguments ending with the completion flag.
+// It reports whether the value of a flag was completed.
+func completeFlagValue(w io.Writer, args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+
+	switch args[len(args)-2] {
+	case "--service":
+		for _, def := range svc.Services() {
+			fmt.Fprintln(w, def.Name)
+			for _, alias := range def.Aliases {
+				fmt.Fprintln(w, alias)
+			}
+		}
+		return true
+	case "--resource":
+		var names []string
+		for _, s := range strings.Split(service, ",") {
+			def, err := svc.LookupService(s)
+			if err != nil {
+				continue
+			}
+			for _, name := range def.ResourceNames() {
+				if !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+		for _, name := range names {
+			fmt.Fprintln(w, name)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
 func getVersion() string {
 	if version != "" {
 		return version
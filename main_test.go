@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -35,3 +36,58 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestCompleteFlagValue(t *testing.T) {
+	cases := []struct {
+		name      string
+		service   string
+		args      []string
+		expected  string
+		completed bool
+	}{
+		{
+			name:      "complete resource of service",
+			service:   "ecr",
+			args:      []string{"awsresq", "--service", "ecr", "--resource", "--generate-bash-completion"},
+			expected:  "repository\n",
+			completed: true,
+		},
+		{
+			name:      "complete resources of services",
+			service:   "ecr,lambda",
+			args:      []string{"awsresq", "--service", "ecr,lambda", "--resource", "--generate-bash-completion"},
+			expected:  "repository\nfunction\n",
+			completed: true,
+		},
+		{
+			name:      "complete resource of undefined service",
+			service:   "custom",
+			args:      []string{"awsresq", "--service", "custom", "--resource", "--generate-bash-completion"},
+			expected:  "",
+			completed: true,
+		},
+		{
+			name:      "complete other flag",
+			args:      []string{"awsresq", "--region", "--generate-bash-completion"},
+			expected:  "",
+			completed: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultService := service
+			service = tt.service
+			var buf bytes.Buffer
+			completed := completeFlagValue(&buf, tt.args)
+
+			if completed != tt.completed {
+				t.Errorf("completeFlagValue() = %v, want %v", completed, tt.completed)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("completeFlagValue() printed %q, want %q", buf.String(), tt.expected)
+			}
+			service = defaultService
+		})
+	}
+}
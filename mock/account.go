@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	iam "github.com/aws/aws-sdk-go-v2/service/iam"
+	organizations "github.com/aws/aws-sdk-go-v2/service/organizations"
+	sts "github.com/aws/aws-sdk-go-v2/service/sts"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsOrganizationsAPI is a mock of awsOrganizationsAPI interface.
+type MockawsOrganizationsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsOrganizationsAPIMockRecorder
+}
+
+// MockawsOrganizationsAPIMockRecorder is the mock recorder for MockawsOrganizationsAPI.
+type MockawsOrganizationsAPIMockRecorder struct {
+	mock *MockawsOrganizationsAPI
+}
+
+// NewMockawsOrganizationsAPI creates a new mock instance.
+func NewMockawsOrganizationsAPI(ctrl *gomock.Controller) *MockawsOrganizationsAPI {
+	mock := &MockawsOrganizationsAPI{ctrl: ctrl}
+	mock.recorder = &MockawsOrganizationsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsOrganizationsAPI) EXPECT() *MockawsOrganizationsAPIMockRecorder {
+	return m.recorder
+}
+
+// ListAccounts mocks base method.
+func (m *MockawsOrganizationsAPI) ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAccounts", varargs...)
+	ret0, _ := ret[0].(*organizations.ListAccountsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockawsOrganizationsAPIMockRecorder) ListAccounts(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockawsOrganizationsAPI)(nil).ListAccounts), varargs...)
+}
+
+// MockawsAccountAliasAPI is a mock of awsAccountAliasAPI interface.
+type MockawsAccountAliasAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsAccountAliasAPIMockRecorder
+}
+
+// MockawsAccountAliasAPIMockRecorder is the mock recorder for MockawsAccountAliasAPI.
+type MockawsAccountAliasAPIMockRecorder struct {
+	mock *MockawsAccountAliasAPI
+}
+
+// NewMockawsAccountAliasAPI creates a new mock instance.
+func NewMockawsAccountAliasAPI(ctrl *gomock.Controller) *MockawsAccountAliasAPI {
+	mock := &MockawsAccountAliasAPI{ctrl: ctrl}
+	mock.recorder = &MockawsAccountAliasAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsAccountAliasAPI) EXPECT() *MockawsAccountAliasAPIMockRecorder {
+	return m.recorder
+}
+
+// ListAccountAliases mocks base method.
+func (m *MockawsAccountAliasAPI) ListAccountAliases(ctx context.Context, params *iam.ListAccountAliasesInput, optFns ...func(*iam.Options)) (*iam.ListAccountAliasesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAccountAliases", varargs...)
+	ret0, _ := ret[0].(*iam.ListAccountAliasesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountAliases indicates an expected call of ListAccountAliases.
+func (mr *MockawsAccountAliasAPIMockRecorder) ListAccountAliases(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountAliases", reflect.TypeOf((*MockawsAccountAliasAPI)(nil).ListAccountAliases), varargs...)
+}
+
+// MockawsCallerIdentityAPI is a mock of awsCallerIdentityAPI interface.
+type MockawsCallerIdentityAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsCallerIdentityAPIMockRecorder
+}
+
+// MockawsCallerIdentityAPIMockRecorder is the mock recorder for MockawsCallerIdentityAPI.
+type MockawsCallerIdentityAPIMockRecorder struct {
+	mock *MockawsCallerIdentityAPI
+}
+
+// NewMockawsCallerIdentityAPI creates a new mock instance.
+func NewMockawsCallerIdentityAPI(ctrl *gomock.Controller) *MockawsCallerIdentityAPI {
+	mock := &MockawsCallerIdentityAPI{ctrl: ctrl}
+	mock.recorder = &MockawsCallerIdentityAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsCallerIdentityAPI) EXPECT() *MockawsCallerIdentityAPIMockRecorder {
+	return m.recorder
+}
+
+// GetCallerIdentity mocks base method.
+func (m *MockawsCallerIdentityAPI) GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCallerIdentity", varargs...)
+	ret0, _ := ret[0].(*sts.GetCallerIdentityOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallerIdentity indicates an expected call of GetCallerIdentity.
+func (mr *MockawsCallerIdentityAPIMockRecorder) GetCallerIdentity(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentity", reflect.TypeOf((*MockawsCallerIdentityAPI)(nil).GetCallerIdentity), varargs...)
+}
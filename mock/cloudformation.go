@@ -94,3 +94,23 @@ func (mr *MockawsCloudformationAPIMockRecorder) ListStackSets(ctx, params interf
 	varargs := append([]interface{}{ctx, params}, optFns...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStackSets", reflect.TypeOf((*MockawsCloudformationAPI)(nil).ListStackSets), varargs...)
 }
+
+// ListStacks mocks base method.
+func (m *MockawsCloudformationAPI) ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListStacks", varargs...)
+	ret0, _ := ret[0].(*cloudformation.ListStacksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStacks indicates an expected call of ListStacks.
+func (mr *MockawsCloudformationAPIMockRecorder) ListStacks(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStacks", reflect.TypeOf((*MockawsCloudformationAPI)(nil).ListStacks), varargs...)
+}
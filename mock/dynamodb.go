@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dynamodb.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsDynamodbAPI is a mock of awsDynamodbAPI interface.
+type MockawsDynamodbAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsDynamodbAPIMockRecorder
+}
+
+// MockawsDynamodbAPIMockRecorder is the mock recorder for MockawsDynamodbAPI.
+type MockawsDynamodbAPIMockRecorder struct {
+	mock *MockawsDynamodbAPI
+}
+
+// NewMockawsDynamodbAPI creates a new mock instance.
+func NewMockawsDynamodbAPI(ctrl *gomock.Controller) *MockawsDynamodbAPI {
+	mock := &MockawsDynamodbAPI{ctrl: ctrl}
+	mock.recorder = &MockawsDynamodbAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsDynamodbAPI) EXPECT() *MockawsDynamodbAPIMockRecorder {
+	return m.recorder
+}
+
+// DescribeGlobalTable mocks base method.
+func (m *MockawsDynamodbAPI) DescribeGlobalTable(ctx context.Context, params *dynamodb.DescribeGlobalTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeGlobalTableOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeGlobalTable", varargs...)
+	ret0, _ := ret[0].(*dynamodb.DescribeGlobalTableOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeGlobalTable indicates an expected call of DescribeGlobalTable.
+func (mr *MockawsDynamodbAPIMockRecorder) DescribeGlobalTable(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeGlobalTable", reflect.TypeOf((*MockawsDynamodbAPI)(nil).DescribeGlobalTable), varargs...)
+}
+
+// DescribeTable mocks base method.
+func (m *MockawsDynamodbAPI) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTable", varargs...)
+	ret0, _ := ret[0].(*dynamodb.DescribeTableOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTable indicates an expected call of DescribeTable.
+func (mr *MockawsDynamodbAPIMockRecorder) DescribeTable(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTable", reflect.TypeOf((*MockawsDynamodbAPI)(nil).DescribeTable), varargs...)
+}
+
+// DescribeTimeToLive mocks base method.
+func (m *MockawsDynamodbAPI) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTimeToLive", varargs...)
+	ret0, _ := ret[0].(*dynamodb.DescribeTimeToLiveOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTimeToLive indicates an expected call of DescribeTimeToLive.
+func (mr *MockawsDynamodbAPIMockRecorder) DescribeTimeToLive(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTimeToLive", reflect.TypeOf((*MockawsDynamodbAPI)(nil).DescribeTimeToLive), varargs...)
+}
+
+// ListBackups mocks base method.
+func (m *MockawsDynamodbAPI) ListBackups(ctx context.Context, params *dynamodb.ListBackupsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListBackupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBackups", varargs...)
+	ret0, _ := ret[0].(*dynamodb.ListBackupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBackups indicates an expected call of ListBackups.
+func (mr *MockawsDynamodbAPIMockRecorder) ListBackups(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBackups", reflect.TypeOf((*MockawsDynamodbAPI)(nil).ListBackups), varargs...)
+}
+
+// ListTables mocks base method.
+func (m *MockawsDynamodbAPI) ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTables", varargs...)
+	ret0, _ := ret[0].(*dynamodb.ListTablesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTables indicates an expected call of ListTables.
+func (mr *MockawsDynamodbAPIMockRecorder) ListTables(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTables", reflect.TypeOf((*MockawsDynamodbAPI)(nil).ListTables), varargs...)
+}
+
+// ListTagsOfResource mocks base method.
+func (m *MockawsDynamodbAPI) ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsOfResource", varargs...)
+	ret0, _ := ret[0].(*dynamodb.ListTagsOfResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsOfResource indicates an expected call of ListTagsOfResource.
+func (mr *MockawsDynamodbAPIMockRecorder) ListTagsOfResource(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsOfResource", reflect.TypeOf((*MockawsDynamodbAPI)(nil).ListTagsOfResource), varargs...)
+}
@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: eks.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	eks "github.com/aws/aws-sdk-go-v2/service/eks"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsEksAPI is a mock of awsEksAPI interface.
+type MockawsEksAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsEksAPIMockRecorder
+}
+
+// MockawsEksAPIMockRecorder is the mock recorder for MockawsEksAPI.
+type MockawsEksAPIMockRecorder struct {
+	mock *MockawsEksAPI
+}
+
+// NewMockawsEksAPI creates a new mock instance.
+func NewMockawsEksAPI(ctrl *gomock.Controller) *MockawsEksAPI {
+	mock := &MockawsEksAPI{ctrl: ctrl}
+	mock.recorder = &MockawsEksAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsEksAPI) EXPECT() *MockawsEksAPIMockRecorder {
+	return m.recorder
+}
+
+// DescribeAddon mocks base method.
+func (m *MockawsEksAPI) DescribeAddon(ctx context.Context, params *eks.DescribeAddonInput, optFns ...func(*eks.Options)) (*eks.DescribeAddonOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAddon", varargs...)
+	ret0, _ := ret[0].(*eks.DescribeAddonOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAddon indicates an expected call of DescribeAddon.
+func (mr *MockawsEksAPIMockRecorder) DescribeAddon(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAddon", reflect.TypeOf((*MockawsEksAPI)(nil).DescribeAddon), varargs...)
+}
+
+// DescribeCluster mocks base method.
+func (m *MockawsEksAPI) DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeCluster", varargs...)
+	ret0, _ := ret[0].(*eks.DescribeClusterOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeCluster indicates an expected call of DescribeCluster.
+func (mr *MockawsEksAPIMockRecorder) DescribeCluster(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeCluster", reflect.TypeOf((*MockawsEksAPI)(nil).DescribeCluster), varargs...)
+}
+
+// DescribeFargateProfile mocks base method.
+func (m *MockawsEksAPI) DescribeFargateProfile(ctx context.Context, params *eks.DescribeFargateProfileInput, optFns ...func(*eks.Options)) (*eks.DescribeFargateProfileOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeFargateProfile", varargs...)
+	ret0, _ := ret[0].(*eks.DescribeFargateProfileOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeFargateProfile indicates an expected call of DescribeFargateProfile.
+func (mr *MockawsEksAPIMockRecorder) DescribeFargateProfile(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeFargateProfile", reflect.TypeOf((*MockawsEksAPI)(nil).DescribeFargateProfile), varargs...)
+}
+
+// DescribeNodegroup mocks base method.
+func (m *MockawsEksAPI) DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeNodegroup", varargs...)
+	ret0, _ := ret[0].(*eks.DescribeNodegroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNodegroup indicates an expected call of DescribeNodegroup.
+func (mr *MockawsEksAPIMockRecorder) DescribeNodegroup(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNodegroup", reflect.TypeOf((*MockawsEksAPI)(nil).DescribeNodegroup), varargs...)
+}
+
+// ListAddons mocks base method.
+func (m *MockawsEksAPI) ListAddons(ctx context.Context, params *eks.ListAddonsInput, optFns ...func(*eks.Options)) (*eks.ListAddonsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAddons", varargs...)
+	ret0, _ := ret[0].(*eks.ListAddonsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAddons indicates an expected call of ListAddons.
+func (mr *MockawsEksAPIMockRecorder) ListAddons(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAddons", reflect.TypeOf((*MockawsEksAPI)(nil).ListAddons), varargs...)
+}
+
+// ListClusters mocks base method.
+func (m *MockawsEksAPI) ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListClusters", varargs...)
+	ret0, _ := ret[0].(*eks.ListClustersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClusters indicates an expected call of ListClusters.
+func (mr *MockawsEksAPIMockRecorder) ListClusters(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusters", reflect.TypeOf((*MockawsEksAPI)(nil).ListClusters), varargs...)
+}
+
+// ListFargateProfiles mocks base method.
+func (m *MockawsEksAPI) ListFargateProfiles(ctx context.Context, params *eks.ListFargateProfilesInput, optFns ...func(*eks.Options)) (*eks.ListFargateProfilesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFargateProfiles", varargs...)
+	ret0, _ := ret[0].(*eks.ListFargateProfilesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFargateProfiles indicates an expected call of ListFargateProfiles.
+func (mr *MockawsEksAPIMockRecorder) ListFargateProfiles(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFargateProfiles", reflect.TypeOf((*MockawsEksAPI)(nil).ListFargateProfiles), varargs...)
+}
+
+// ListNodegroups mocks base method.
+func (m *MockawsEksAPI) ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListNodegroups", varargs...)
+	ret0, _ := ret[0].(*eks.ListNodegroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodegroups indicates an expected call of ListNodegroups.
+func (mr *MockawsEksAPIMockRecorder) ListNodegroups(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodegroups", reflect.TypeOf((*MockawsEksAPI)(nil).ListNodegroups), varargs...)
+}
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: elb.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	elasticloadbalancing "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsElbAPI is a mock of awsElbAPI interface.
+type MockawsElbAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsElbAPIMockRecorder
+}
+
+// MockawsElbAPIMockRecorder is the mock recorder for MockawsElbAPI.
+type MockawsElbAPIMockRecorder struct {
+	mock *MockawsElbAPI
+}
+
+// NewMockawsElbAPI creates a new mock instance.
+func NewMockawsElbAPI(ctrl *gomock.Controller) *MockawsElbAPI {
+	mock := &MockawsElbAPI{ctrl: ctrl}
+	mock.recorder = &MockawsElbAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsElbAPI) EXPECT() *MockawsElbAPIMockRecorder {
+	return m.recorder
+}
+
+// DescribeLoadBalancers mocks base method.
+func (m *MockawsElbAPI) DescribeLoadBalancers(ctx context.Context, params *elasticloadbalancing.DescribeLoadBalancersInput, optFns ...func(*elasticloadbalancing.Options)) (*elasticloadbalancing.DescribeLoadBalancersOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeLoadBalancers", varargs...)
+	ret0, _ := ret[0].(*elasticloadbalancing.DescribeLoadBalancersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLoadBalancers indicates an expected call of DescribeLoadBalancers.
+func (mr *MockawsElbAPIMockRecorder) DescribeLoadBalancers(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLoadBalancers", reflect.TypeOf((*MockawsElbAPI)(nil).DescribeLoadBalancers), varargs...)
+}
+
+// DescribeTags mocks base method.
+func (m *MockawsElbAPI) DescribeTags(ctx context.Context, params *elasticloadbalancing.DescribeTagsInput, optFns ...func(*elasticloadbalancing.Options)) (*elasticloadbalancing.DescribeTagsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeTags", varargs...)
+	ret0, _ := ret[0].(*elasticloadbalancing.DescribeTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTags indicates an expected call of DescribeTags.
+func (mr *MockawsElbAPIMockRecorder) DescribeTags(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTags", reflect.TypeOf((*MockawsElbAPI)(nil).DescribeTags), varargs...)
+}
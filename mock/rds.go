@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rds.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	rds "github.com/aws/aws-sdk-go-v2/service/rds"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsRdsAPI is a mock of awsRdsAPI interface.
+type MockawsRdsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsRdsAPIMockRecorder
+}
+
+// MockawsRdsAPIMockRecorder is the mock recorder for MockawsRdsAPI.
+type MockawsRdsAPIMockRecorder struct {
+	mock *MockawsRdsAPI
+}
+
+// NewMockawsRdsAPI creates a new mock instance.
+func NewMockawsRdsAPI(ctrl *gomock.Controller) *MockawsRdsAPI {
+	mock := &MockawsRdsAPI{ctrl: ctrl}
+	mock.recorder = &MockawsRdsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsRdsAPI) EXPECT() *MockawsRdsAPIMockRecorder {
+	return m.recorder
+}
+
+// DescribeDBClusterSnapshots mocks base method.
+func (m *MockawsRdsAPI) DescribeDBClusterSnapshots(ctx context.Context, params *rds.DescribeDBClusterSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBClusterSnapshots", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBClusterSnapshotsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBClusterSnapshots indicates an expected call of DescribeDBClusterSnapshots.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBClusterSnapshots(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBClusterSnapshots", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBClusterSnapshots), varargs...)
+}
+
+// DescribeDBClusters mocks base method.
+func (m *MockawsRdsAPI) DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBClusters", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBClustersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBClusters indicates an expected call of DescribeDBClusters.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBClusters(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBClusters", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBClusters), varargs...)
+}
+
+// DescribeDBInstances mocks base method.
+func (m *MockawsRdsAPI) DescribeDBInstances(ctx context.Context, params *rds.DescribeDBInstancesInput, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBInstances", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBInstancesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBInstances indicates an expected call of DescribeDBInstances.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBInstances(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBInstances", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBInstances), varargs...)
+}
+
+// DescribeDBParameterGroups mocks base method.
+func (m *MockawsRdsAPI) DescribeDBParameterGroups(ctx context.Context, params *rds.DescribeDBParameterGroupsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBParameterGroups", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBParameterGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBParameterGroups indicates an expected call of DescribeDBParameterGroups.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBParameterGroups(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBParameterGroups", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBParameterGroups), varargs...)
+}
+
+// DescribeDBSnapshots mocks base method.
+func (m *MockawsRdsAPI) DescribeDBSnapshots(ctx context.Context, params *rds.DescribeDBSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBSnapshots", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBSnapshotsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBSnapshots indicates an expected call of DescribeDBSnapshots.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBSnapshots(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBSnapshots", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBSnapshots), varargs...)
+}
+
+// DescribeDBSubnetGroups mocks base method.
+func (m *MockawsRdsAPI) DescribeDBSubnetGroups(ctx context.Context, params *rds.DescribeDBSubnetGroupsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBSubnetGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeDBSubnetGroups", varargs...)
+	ret0, _ := ret[0].(*rds.DescribeDBSubnetGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDBSubnetGroups indicates an expected call of DescribeDBSubnetGroups.
+func (mr *MockawsRdsAPIMockRecorder) DescribeDBSubnetGroups(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDBSubnetGroups", reflect.TypeOf((*MockawsRdsAPI)(nil).DescribeDBSubnetGroups), varargs...)
+}
+
+// ListTagsForResource mocks base method.
+func (m *MockawsRdsAPI) ListTagsForResource(ctx context.Context, params *rds.ListTagsForResourceInput, optFns ...func(*rds.Options)) (*rds.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResource", varargs...)
+	ret0, _ := ret[0].(*rds.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResource indicates an expected call of ListTagsForResource.
+func (mr *MockawsRdsAPIMockRecorder) ListTagsForResource(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockawsRdsAPI)(nil).ListTagsForResource), varargs...)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: region.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsRegionAPI is a mock of awsRegionAPI interface.
+type MockawsRegionAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsRegionAPIMockRecorder
+}
+
+// MockawsRegionAPIMockRecorder is the mock recorder for MockawsRegionAPI.
+type MockawsRegionAPIMockRecorder struct {
+	mock *MockawsRegionAPI
+}
+
+// NewMockawsRegionAPI creates a new mock instance.
+func NewMockawsRegionAPI(ctrl *gomock.Controller) *MockawsRegionAPI {
+	mock := &MockawsRegionAPI{ctrl: ctrl}
+	mock.recorder = &MockawsRegionAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsRegionAPI) EXPECT() *MockawsRegionAPIMockRecorder {
+	return m.recorder
+}
+
+// DescribeRegions mocks base method.
+func (m *MockawsRegionAPI) DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeRegions", varargs...)
+	ret0, _ := ret[0].(*ec2.DescribeRegionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRegions indicates an expected call of DescribeRegions.
+func (mr *MockawsRegionAPIMockRecorder) DescribeRegions(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRegions", reflect.TypeOf((*MockawsRegionAPI)(nil).DescribeRegions), varargs...)
+}
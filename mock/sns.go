@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sns.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sns "github.com/aws/aws-sdk-go-v2/service/sns"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsSnsAPI is a mock of awsSnsAPI interface.
+type MockawsSnsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsSnsAPIMockRecorder
+}
+
+// MockawsSnsAPIMockRecorder is the mock recorder for MockawsSnsAPI.
+type MockawsSnsAPIMockRecorder struct {
+	mock *MockawsSnsAPI
+}
+
+// NewMockawsSnsAPI creates a new mock instance.
+func NewMockawsSnsAPI(ctrl *gomock.Controller) *MockawsSnsAPI {
+	mock := &MockawsSnsAPI{ctrl: ctrl}
+	mock.recorder = &MockawsSnsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsSnsAPI) EXPECT() *MockawsSnsAPIMockRecorder {
+	return m.recorder
+}
+
+// GetTopicAttributes mocks base method.
+func (m *MockawsSnsAPI) GetTopicAttributes(ctx context.Context, params *sns.GetTopicAttributesInput, optFns ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTopicAttributes", varargs...)
+	ret0, _ := ret[0].(*sns.GetTopicAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopicAttributes indicates an expected call of GetTopicAttributes.
+func (mr *MockawsSnsAPIMockRecorder) GetTopicAttributes(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicAttributes", reflect.TypeOf((*MockawsSnsAPI)(nil).GetTopicAttributes), varargs...)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockawsSnsAPI) ListSubscriptions(ctx context.Context, params *sns.ListSubscriptionsInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSubscriptions", varargs...)
+	ret0, _ := ret[0].(*sns.ListSubscriptionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockawsSnsAPIMockRecorder) ListSubscriptions(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockawsSnsAPI)(nil).ListSubscriptions), varargs...)
+}
+
+// ListTagsForResource mocks base method.
+func (m *MockawsSnsAPI) ListTagsForResource(ctx context.Context, params *sns.ListTagsForResourceInput, optFns ...func(*sns.Options)) (*sns.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResource", varargs...)
+	ret0, _ := ret[0].(*sns.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResource indicates an expected call of ListTagsForResource.
+func (mr *MockawsSnsAPIMockRecorder) ListTagsForResource(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockawsSnsAPI)(nil).ListTagsForResource), varargs...)
+}
+
+// ListTopics mocks base method.
+func (m *MockawsSnsAPI) ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTopics", varargs...)
+	ret0, _ := ret[0].(*sns.ListTopicsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTopics indicates an expected call of ListTopics.
+func (mr *MockawsSnsAPIMockRecorder) ListTopics(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopics", reflect.TypeOf((*MockawsSnsAPI)(nil).ListTopics), varargs...)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sqs.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockawsSqsAPI is a mock of awsSqsAPI interface.
+type MockawsSqsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockawsSqsAPIMockRecorder
+}
+
+// MockawsSqsAPIMockRecorder is the mock recorder for MockawsSqsAPI.
+type MockawsSqsAPIMockRecorder struct {
+	mock *MockawsSqsAPI
+}
+
+// NewMockawsSqsAPI creates a new mock instance.
+func NewMockawsSqsAPI(ctrl *gomock.Controller) *MockawsSqsAPI {
+	mock := &MockawsSqsAPI{ctrl: ctrl}
+	mock.recorder = &MockawsSqsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockawsSqsAPI) EXPECT() *MockawsSqsAPIMockRecorder {
+	return m.recorder
+}
+
+// GetQueueAttributes mocks base method.
+func (m *MockawsSqsAPI) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetQueueAttributes", varargs...)
+	ret0, _ := ret[0].(*sqs.GetQueueAttributesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueAttributes indicates an expected call of GetQueueAttributes.
+func (mr *MockawsSqsAPIMockRecorder) GetQueueAttributes(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueAttributes", reflect.TypeOf((*MockawsSqsAPI)(nil).GetQueueAttributes), varargs...)
+}
+
+// GetQueueUrl mocks base method.
+func (m *MockawsSqsAPI) GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetQueueUrl", varargs...)
+	ret0, _ := ret[0].(*sqs.GetQueueUrlOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueUrl indicates an expected call of GetQueueUrl.
+func (mr *MockawsSqsAPIMockRecorder) GetQueueUrl(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueUrl", reflect.TypeOf((*MockawsSqsAPI)(nil).GetQueueUrl), varargs...)
+}
+
+// ListQueueTags mocks base method.
+func (m *MockawsSqsAPI) ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListQueueTags", varargs...)
+	ret0, _ := ret[0].(*sqs.ListQueueTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueueTags indicates an expected call of ListQueueTags.
+func (mr *MockawsSqsAPIMockRecorder) ListQueueTags(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueueTags", reflect.TypeOf((*MockawsSqsAPI)(nil).ListQueueTags), varargs...)
+}
+
+// ListQueues mocks base method.
+func (m *MockawsSqsAPI) ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListQueues", varargs...)
+	ret0, _ := ret[0].(*sqs.ListQueuesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueues indicates an expected call of ListQueues.
+func (mr *MockawsSqsAPIMockRecorder) ListQueues(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueues", reflect.TypeOf((*MockawsSqsAPI)(nil).ListQueues), varargs...)
+}
@@ -0,0 +1,91 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+type awsOrganizationsAPI interface {
+	ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
+}
+
+type awsAccountAliasAPI interface {
+	ListAccountAliases(ctx context.Context, params *iam.ListAccountAliasesInput, optFns ...func(*iam.Options)) (*iam.ListAccountAliasesOutput, error)
+}
+
+type awsCallerIdentityAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// Account is an AWS account searched in multi-account mode.
+type Account struct {
+	ID string
+	// Name is the account name registered in AWS Organizations, if known.
+	Name string
+}
+
+// ListOrganizationAccounts returns the active member accounts of the organization cfg belongs to.
+func ListOrganizationAccounts(ctx context.Context, cfg aws.Config, partition Partition) ([]Account, error) {
+	return listOrganizationAccounts(ctx, organizations.NewFromConfig(cfg, func(o *organizations.Options) {
+		o.Region = partition.GlobalRegion("organizations")
+	}))
+}
+
+func listOrganizationAccounts(ctx context.Context, client awsOrganizationsAPI) ([]Account, error) {
+	var accounts []Account
+
+	paginator := organizations.NewListAccountsPaginator(client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, account := range output.Accounts {
+			if account.Status != types.AccountStatusActive {
+				continue
+			}
+			accounts = append(accounts, Account{
+				ID:   aws.ToString(account.Id),
+				Name: aws.ToString(account.Name),
+			})
+		}
+	}
+
+	return accounts, nil
+}
+
+// LookupAccountAlias returns the IAM account alias of the account cfg belongs to.
+// Empty is returned when the account has no alias.
+func LookupAccountAlias(ctx context.Context, cfg aws.Config, partition Partition) (string, error) {
+	return lookupAccountAlias(ctx, iam.NewFromConfig(cfg, func(o *iam.Options) {
+		o.Region = partition.GlobalRegion("iam")
+	}))
+}
+
+func lookupAccountAlias(ctx context.Context, client awsAccountAliasAPI) (string, error) {
+	output, err := client.ListAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+	if err != nil {
+		return "", err
+	}
+	// an account has at most one alias
+	if len(output.AccountAliases) == 0 {
+		return "", nil
+	}
+	return output.AccountAliases[0], nil
+}
+
+// lookupCallerAccount returns the ID of the account the credentials of client belong to.
+func lookupCallerAccount(ctx context.Context, client awsCallerIdentityAPI) (string, error) {
+	output, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.Account), nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestListOrganizationAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsOrganizationsAPI(ctrl)
+
+	mc.EXPECT().
+		ListAccounts(gomock.Any(), &organizations.ListAccountsInput{}).
+		Return(&organizations.ListAccountsOutput{
+			Accounts: []types.Account{
+				{
+					Id:     aws.String("111111111111"),
+					Name:   aws.String("production"),
+					Status: types.AccountStatusActive,
+				},
+				{
+					Id:     aws.String("222222222222"),
+					Name:   aws.String("closed"),
+					Status: types.AccountStatusSuspended,
+				},
+			},
+			NextToken: aws.String("token"),
+		}, nil)
+	mc.EXPECT().
+		ListAccounts(gomock.Any(), &organizations.ListAccountsInput{NextToken: aws.String("token")}).
+		Return(&organizations.ListAccountsOutput{
+			Accounts: []types.Account{
+				{
+					Id:     aws.String("333333333333"),
+					Name:   aws.String("staging"),
+					Status: types.AccountStatusActive,
+				},
+			},
+		}, nil)
+
+	actual, err := listOrganizationAccounts(context.TODO(), mc)
+	if err != nil {
+		t.Errorf("expected nil, but got %v", err.Error())
+	}
+
+	expected := []Account{
+		{ID: "111111111111", Name: "production"},
+		{ID: "333333333333", Name: "staging"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestLookupAccountAlias(t *testing.T) {
+	cases := []struct {
+		name     string
+		aliases  []string
+		expected string
+	}{
+		{
+			name:     "lookup account with alias",
+			aliases:  []string{"example-production"},
+			expected: "example-production",
+		},
+		{
+			name:     "lookup account without alias",
+			aliases:  []string{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mc := mock_service.NewMockawsAccountAliasAPI(ctrl)
+			mc.EXPECT().
+				ListAccountAliases(gomock.Any(), &iam.ListAccountAliasesInput{}).
+				Return(&iam.ListAccountAliasesOutput{
+					AccountAliases: tt.aliases,
+				}, nil)
+
+			actual, err := lookupAccountAlias(context.TODO(), mc)
+			if err != nil {
+				t.Errorf("expected nil, but got %v", err.Error())
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// ResourceARN is an ARN resolved into the resource of a registered service it identifies.
+type ResourceARN struct {
+	arn.ARN
+	// Service and Resource are the names of the service and the resource it identifies.
+	Service  string
+	Resource string
+	// Type and ID split the resource part of the ARN, such as "instance" and "i-0123" of "instance/i-0123".
+	// ID keeps the remaining separators, such as "cluster/service" of ECS services.
+	Type string
+	ID   string
+}
+
+// ParseResourceARN parses s and resolves the service and the resource it identifies.
+func ParseResourceARN(s string) (ResourceARN, error) {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return ResourceARN{}, fmt.Errorf("invalid ARN: '%s'", s)
+	}
+	// the ARNs of log groups may end with ":*"
+	parsed.Resource = strings.TrimSuffix(parsed.Resource, ":*")
+
+	resourceARN := ResourceARN{ARN: parsed}
+	resourceARN.Type, resourceARN.ID = splitARNResource(parsed.Resource)
+
+	// nested types such as "table/*/backup" of DynamoDB backups are matched before the types they are nested in
+	for _, nested := range []bool{true, false} {
+		for _, def := range Services() {
+			if def.namespace() != parsed.Service {
+				continue
+			}
+			for _, r := range def.Resources {
+				for _, t := range r.arnTypes {
+					if strings.Contains(t, "/") != nested {
+						continue
+					}
+					if nested {
+						typ, id, ok := matchNestedARNType(t, parsed.Resource)
+						if !ok {
+							continue
+						}
+						resourceARN.Type, resourceARN.ID = typ, id
+					} else if t != resourceARN.Type {
+						continue
+					}
+					resourceARN.Service = def.Name
+					resourceARN.Resource = r.Name
+					return resourceARN, nil
+				}
+			}
+		}
+	}
+	return ResourceARN{}, fmt.Errorf("ARN of %s resource type '%s' not supported: '%s'", parsed.Service, resourceARN.Type, s)
+}
+
+// splitARNResource splits the resource part of an ARN at the first separator, either '/' or ':'.
+// A resource part without separator has no type, and it is the ID as a whole.
+func splitARNResource(resource string) (string, string) {
+	i := strings.IndexAny(resource, "/:")
+	if i < 0 {
+		return "", resource
+	}
+	return resource[:i], resource[i+1:]
+}
+
+// matchNestedARNType matches the leading segments of the resource part of an ARN with a nested type,
+// whose segments are glob patterns. It returns the matched segments as the type and the rest as the ID.
+func matchNestedARNType(t, resource string) (string, string, bool) {
+	patterns := strings.Split(t, "/")
+	segments := strings.SplitN(resource, "/", len(patterns)+1)
+	if len(segments) <= len(patterns) {
+		return "", "", false
+	}
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, segments[i]); !ok {
+			return "", "", false
+		}
+	}
+	return strings.Join(segments[:len(patterns)], "/"), segments[len(patterns)], true
+}
+
+// lastSegment returns the part of id following the last '/', such as the name of an IAM role with a path.
+func lastSegment(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
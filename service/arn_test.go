@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestParseResourceARN(t *testing.T) {
+	cases := []struct {
+		name      string
+		arn       string
+		service   string
+		resource  string
+		id        string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "ec2 instance",
+			arn:      "arn:aws:ec2:ap-northeast-1:012345678901:instance/i-1234567890abcdef0",
+			service:  "ec2",
+			resource: "instance",
+			id:       "i-1234567890abcdef0",
+		},
+		{
+			name:     "ecs service including the cluster",
+			arn:      "arn:aws:ecs:ap-northeast-1:012345678901:service/production/web",
+			service:  "ecs",
+			resource: "service",
+			id:       "production/web",
+		},
+		{
+			name:     "lambda function separated by colon",
+			arn:      "arn:aws:lambda:ap-northeast-1:012345678901:function:handler",
+			service:  "lambda",
+			resource: "function",
+			id:       "handler",
+		},
+		{
+			name:     "log group ending with wildcard",
+			arn:      "arn:aws:logs:ap-northeast-1:012345678901:log-group:/app:*",
+			service:  "logs",
+			resource: "log-group",
+			id:       "/app",
+		},
+		{
+			name:     "efs file system in service namespace",
+			arn:      "arn:aws:elasticfilesystem:ap-northeast-1:012345678901:file-system/fs-01234567",
+			service:  "efs",
+			resource: "file-system",
+			id:       "fs-01234567",
+		},
+		{
+			name:     "s3 bucket without resource type",
+			arn:      "arn:aws:s3:::example-bucket",
+			service:  "s3",
+			resource: "bucket",
+			id:       "example-bucket",
+		},
+		{
+			name:     "dynamodb table",
+			arn:      "arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders",
+			service:  "dynamodb",
+			resource: "table",
+			id:       "orders",
+		},
+		{
+			name:     "dynamodb backup nested in table",
+			arn:      "arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders/backup/01234567890123-abcdefgh",
+			service:  "dynamodb",
+			resource: "backup",
+			id:       "01234567890123-abcdefgh",
+		},
+		{
+			name:     "sqs queue without resource type",
+			arn:      "arn:aws:sqs:ap-northeast-1:012345678901:orders",
+			service:  "sqs",
+			resource: "queue",
+			id:       "orders",
+		},
+		{
+			name:     "sns topic without resource type",
+			arn:      "arn:aws:sns:ap-northeast-1:012345678901:notifications",
+			service:  "sns",
+			resource: "topic",
+			id:       "notifications",
+		},
+		{
+			name:      "sns subscription not supported",
+			arn:       "arn:aws:sns:ap-northeast-1:012345678901:notifications:0123abcd-4567-89ef-0123-456789abcdef",
+			wantErr:   true,
+			expectErr: "ARN of sns resource type 'notifications' not supported",
+		},
+		{
+			name:     "elbv2 application load balancer nested in load balancer type",
+			arn:      "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:loadbalancer/app/web/0123456789abcdef",
+			service:  "elbv2",
+			resource: "load-balancer",
+			id:       "web/0123456789abcdef",
+		},
+		{
+			name:     "elbv2 listener",
+			arn:      "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:listener/app/web/0123456789abcdef/fedcba9876543210",
+			service:  "elbv2",
+			resource: "listener",
+			id:       "app/web/0123456789abcdef/fedcba9876543210",
+		},
+		{
+			name:     "classic elb load balancer",
+			arn:      "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:loadbalancer/legacy",
+			service:  "elb",
+			resource: "load-balancer",
+			id:       "legacy",
+		},
+		{
+			name:     "eks nodegroup including the cluster",
+			arn:      "arn:aws:eks:ap-northeast-1:012345678901:nodegroup/production/default/0123abcd-4567-89ef-0123-456789abcdef",
+			service:  "eks",
+			resource: "nodegroup",
+			id:       "production/default/0123abcd-4567-89ef-0123-456789abcdef",
+		},
+		{
+			name:      "unsupported resource type",
+			arn:       "arn:aws:ec2:ap-northeast-1:012345678901:subnet/subnet-01234567",
+			wantErr:   true,
+			expectErr: "ARN of ec2 resource type 'subnet' not supported",
+		},
+		{
+			name:      "invalid arn",
+			arn:       "i-1234567890abcdef0",
+			wantErr:   true,
+			expectErr: "invalid ARN: 'i-1234567890abcdef0'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseResourceARN(tt.arn)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual.Service != tt.service || actual.Resource != tt.resource || actual.ID != tt.id {
+				t.Errorf("expected %s %s %s, but got %s %s %s", tt.service, tt.resource, tt.id, actual.Service, actual.Resource, actual.ID)
+			}
+		})
+	}
+}
+
+func TestQueryARN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mcEc2 := mock_service.NewMockawsEc2API(ctrl)
+	mcEc2.EXPECT().
+		DescribeInstances(gomock.Any(), &ec2.DescribeInstancesInput{
+			InstanceIds: []string{"i-1234567890abcdef0"},
+		}).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{InstanceId: aws.String("i-1234567890abcdef0")},
+					},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	mcEcs := mock_service.NewMockawsEcsAPI(ctrl)
+	mcEcs.EXPECT().
+		DescribeServices(gomock.Any(), &ecs.DescribeServicesInput{
+			Cluster:  aws.String("production"),
+			Services: []string{"arn:aws:ecs:ap-northeast-1:012345678901:service/production/web"},
+			Include:  []ecstypes.ServiceField{ecstypes.ServiceFieldTags},
+		}).
+		Return(&ecs.DescribeServicesOutput{
+			Services: []ecstypes.Service{
+				{ServiceArn: aws.String("arn:aws:ecs:ap-northeast-1:012345678901:service/production/web")},
+			},
+		}, nil).
+		Times(1)
+
+	mcIam := mock_service.NewMockawsIamAPI(ctrl)
+	mcIam.EXPECT().
+		GetRole(gomock.Any(), &iam.GetRoleInput{RoleName: aws.String("admin")}).
+		Return(&iam.GetRoleOutput{
+			Role: &iamtypes.Role{
+				Arn:      aws.String("arn:aws:iam::012345678901:role/infra/admin"),
+				RoleName: aws.String("admin"),
+			},
+		}, nil).
+		Times(1)
+
+	mcS3 := mock_service.NewMockawsS3API(ctrl)
+	mcS3.EXPECT().
+		ListBuckets(gomock.Any(), gomock.Any()).
+		Return(&s3.ListBucketsOutput{
+			Buckets: []s3types.Bucket{
+				{Name: aws.String("another-bucket")},
+				{Name: aws.String("example-bucket")},
+			},
+		}, nil).
+		Times(1)
+
+	cases := []struct {
+		name     string
+		arn      string
+		region   string
+		client   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "describe ec2 instance by id",
+			arn:      "arn:aws:ec2:ap-northeast-1:012345678901:instance/i-1234567890abcdef0",
+			region:   "ap-northeast-1",
+			client:   mcEc2,
+			expected: ec2types.Instance{InstanceId: aws.String("i-1234567890abcdef0")},
+		},
+		{
+			name:     "describe ecs service in the cluster of the arn",
+			arn:      "arn:aws:ecs:ap-northeast-1:012345678901:service/production/web",
+			region:   "ap-northeast-1",
+			client:   mcEcs,
+			expected: ecstypes.Service{ServiceArn: aws.String("arn:aws:ecs:ap-northeast-1:012345678901:service/production/web")},
+		},
+		{
+			name:   "get iam role by name without path",
+			arn:    "arn:aws:iam::012345678901:role/infra/admin",
+			region: "us-east-1",
+			client: mcIam,
+			expected: iamtypes.Role{
+				Arn:      aws.String("arn:aws:iam::012345678901:role/infra/admin"),
+				RoleName: aws.String("admin"),
+			},
+		},
+		{
+			name:     "select s3 bucket of the arn from the list",
+			arn:      "arn:aws:s3:::example-bucket",
+			region:   "us-east-1",
+			client:   mcS3,
+			expected: s3types.Bucket{Name: aws.String("example-bucket")},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceARN, err := ParseResourceARN(tt.arn)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			opt := QueryOption{
+				Clients: NewClientCache(),
+				ARN:     &resourceARN,
+			}
+			opt.Clients.put("", tt.region, resourceARN.Service, tt.client)
+			def, _ := LookupService(resourceARN.Service)
+			api := def.New(config, []string{tt.region}, opt)
+
+			actual, err := api.Query(resourceARN.Resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual.Results) != 1 {
+				t.Fatalf("expected 1 result, but got %d", len(actual.Results))
+			}
+			if !gomock.Eq(tt.expected).Matches(actual.Results[0]) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results[0])
+			}
+		})
+	}
+}
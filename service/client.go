@@ -0,0 +1,53 @@
+package service
+
+import "sync"
+
+type clientKey struct {
+	account string
+	region  string
+	service string
+}
+
+// ClientCache holds the API clients built by the services, so that every query in the same account,
+// region and service shares a single client. It is safe for concurrent use.
+type ClientCache struct {
+	mu      sync.Mutex
+	clients map[clientKey]interface{}
+}
+
+func NewClientCache() *ClientCache {
+	return &ClientCache{
+		clients: map[clientKey]interface{}{},
+	}
+}
+
+// put caches client for the account, region and service.
+func (c *ClientCache) put(account, region, service string, client interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clients[clientKey{account, region, service}] = client
+}
+
+// cachedClient returns the client cached for the account, region and service,
+// building it with newClient on the first call.
+func cachedClient[C any](c *ClientCache, account, region, service string, newClient func() C) C {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := clientKey{account, region, service}
+	if client, ok := c.clients[key]; ok {
+		return client.(C)
+	}
+	client := newClient()
+	c.clients[key] = client
+	return client
+}
+
+// clientCache returns the client cache of opt, or a new one when opt has none.
+func clientCache(opt QueryOption) *ClientCache {
+	if opt.Clients != nil {
+		return opt.Clients
+	}
+	return NewClientCache()
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestCachedClient(t *testing.T) {
+	cache := NewClientCache()
+	var built int32
+	newClient := func(name string) func() string {
+		return func() string {
+			atomic.AddInt32(&built, 1)
+			return name
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			region := fmt.Sprintf("region-%d", i%4)
+			client := cachedClient(cache, "012345678901", region, "ec2", newClient(region))
+			if client != region {
+				t.Errorf("expected %v, but got %v", region, client)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if built != 4 {
+		t.Errorf("expected 4 clients built, but got %v", built)
+	}
+
+	cases := []struct {
+		name     string
+		account  string
+		region   string
+		service  string
+		expected string
+	}{
+		{
+			name:     "reuse cached client",
+			account:  "012345678901",
+			region:   "region-0",
+			service:  "ec2",
+			expected: "region-0",
+		},
+		{
+			name:     "build client for another account",
+			account:  "123456789012",
+			region:   "region-0",
+			service:  "ec2",
+			expected: "new",
+		},
+		{
+			name:     "build client for another service",
+			account:  "012345678901",
+			region:   "region-0",
+			service:  "ecs",
+			expected: "new",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := cachedClient(cache, tt.account, tt.region, tt.service, newClient("new"))
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// TestClientCacheAllRegions queries every region of the partition with services sharing a client cache,
+// as searching with --region all does. Run it with -race to detect unsynchronized access to the clients.
+func TestClientCacheAllRegions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mcEc2 := mock_service.NewMockawsEc2API(ctrl)
+	mcEcs := mock_service.NewMockawsEcsAPI(ctrl)
+
+	mcEc2.EXPECT().
+		DescribeVpcs(gomock.Any(), &ec2.DescribeVpcsInput{}).
+		Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []ec2types.Vpc{
+				{
+					VpcId: aws.String("vpc-0123456789abcdef0"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcEcs.EXPECT().
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
+		Return(&ecs.ListClustersOutput{}, nil).
+		AnyTimes()
+
+	partition, _ := LookupPartition("aws")
+	regions := append(append([]string{}, partition.Regions...), partition.OptInRegions...)
+
+	cfg, _ := config.LoadDefaultConfig(context.TODO())
+	opt := QueryOption{
+		MaxConcurrency: 8,
+		Clients:        NewClientCache(),
+	}
+	for _, region := range regions {
+		opt.Clients.put("", region, "ec2", mcEc2)
+		opt.Clients.put("", region, "ecs", mcEcs)
+	}
+
+	cases := []struct {
+		name          string
+		api           AwsresqAPI
+		resource      string
+		expectedCount int
+	}{
+		{
+			name:          "query ec2 vpc in all regions",
+			api:           NewAwsresqEc2API(cfg, regions, opt),
+			resource:      "vpc",
+			expectedCount: len(regions),
+		},
+		{
+			name:          "query ecs cluster in all regions",
+			api:           NewAwsresqEcsAPI(cfg, regions, opt),
+			resource:      "cluster",
+			expectedCount: 0,
+		},
+	}
+
+	var wg sync.WaitGroup
+	for _, tt := range cases {
+		wg.Add(1)
+		go func(api AwsresqAPI, resource string, expectedCount int) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				actual, err := api.Query(resource)
+				if err != nil {
+					t.Errorf("expected nil, but got %v", err)
+					return
+				}
+				if len(actual.Results) != expectedCount {
+					t.Errorf("expected %v results of %s, but got %v", expectedCount, resource, len(actual.Results))
+				}
+			}
+		}(tt.api, tt.resource, tt.expectedCount)
+	}
+	wg.Wait()
+}
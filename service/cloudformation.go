@@ -3,136 +3,188 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 )
 
 type awsCloudformationAPI interface {
 	ListStackSets(ctx context.Context, params *cloudformation.ListStackSetsInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackSetsOutput, error)
 	DescribeStacks(ctx context.Context, params *cloudformation.DescribeStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStacksOutput, error)
 	DescribeStackSet(ctx context.Context, params *cloudformation.DescribeStackSetInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeStackSetOutput, error)
+	ListStacks(ctx context.Context, params *cloudformation.ListStacksInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStacksOutput, error)
 }
 
+var cloudformationService = register(ServiceDefinition{
+	Name:        "cloudformation",
+	Description: "AWS CloudFormation",
+	Resources: []ResourceDefinition{
+		newResource("stack", "CloudFormation stacks", []string{"StackName", "StackStatus", "CreationTime"},
+			func(api *AwsresqCloudformationAPI) ResourceQueryAPI { return api.queryCloudformationStack }).
+			withARN("stack").
+			withFilters(filterDefinition{name: "stack-status"}),
+		newResource("stack-set", "CloudFormation StackSets", []string{"StackSetName", "Status", "PermissionModel"},
+			func(api *AwsresqCloudformationAPI) ResourceQueryAPI { return api.queryCloudformationStackSet }).
+			withARN("stackset").
+			withFilters(singleValueFilters("status")...),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqCloudformationAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqCloudformationAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsCloudformationAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqCloudformationAPI(c aws.Config, region []string) *AwsresqCloudformationAPI {
+func NewAwsresqCloudformationAPI(c aws.Config, region []string, opt QueryOption) *AwsresqCloudformationAPI {
 	return &AwsresqCloudformationAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsCloudformationAPI, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqCloudformationAPI) Validate(resource string) bool {
-	validResoruces := []string{
-		"stack",
-		"stack-set",
-	}
-
-	return slices.Contains(validResoruces, resource)
+func (api *AwsresqCloudformationAPI) Validate(resource string) bool {
+	return cloudformationService.Validate(resource)
 }
 
-func (api AwsresqCloudformationAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "cloudformation",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "stack":
-		apiQuery = api.queryCloudformationStack
-	case "stack-set":
-		apiQuery = api.queryCloudformationStackSet
-	default:
-		return nil, fmt.Errorf("resource %s not supported in cloudformation service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			if result.Results != nil {
-				resultList.Results = append(resultList.Results, result.Results...)
-			}
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqCloudformationAPI) DefaultColumns(resource string) []string {
+	return cloudformationService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqCloudformationAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqCloudformationAPI) queryCloudformationStack(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "cloudformation",
-		Resource: "stack",
-	}
+func (api *AwsresqCloudformationAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return cloudformationService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = cloudformation.NewFromConfig(api.awsCfg, func(o *cloudformation.Options) {
+func (api *AwsresqCloudformationAPI) client(region string) awsCloudformationAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "cloudformation", func() awsCloudformationAPI {
+		return cloudformation.NewFromConfig(api.awsCfg, func(o *cloudformation.Options) {
 			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[region].DescribeStacks(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("error querying cloudformation stacks for region %s", region)
-		return
-	}
-
-	for _, stack := range listOutput.Stacks {
-		resultList.Results = append(resultList.Results, stack)
-	}
+	})
+}
 
-	ch <- resultList
+func (api *AwsresqCloudformationAPI) queryCloudformationStack(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsCloudformationAPI, *cloudformation.DescribeStacksOutput, types.Stack, types.Stack]{
+		service:  "cloudformation",
+		resource: "stack",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsCloudformationAPI, _ string, token *string) (*cloudformation.DescribeStacksOutput, error) {
+			if statuses := api.opt.Filters.values("stack-status"); len(statuses) > 0 {
+				return listStacksByStatus(ctx, client, statuses, token)
+			}
+			return client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{NextToken: token})
+		},
+		lookup: func(ctx context.Context, client awsCloudformationAPI, target ResourceARN) (string, *cloudformation.DescribeStacksOutput, error) {
+			output, err := client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+				StackName: aws.String(target.String()),
+			})
+			return "", output, err
+		},
+		items: func(output *cloudformation.DescribeStacksOutput) ([]types.Stack, *string) {
+			return output.Stacks, output.NextToken
+		},
+		tags: func(_ context.Context, _ awsCloudformationAPI, stack types.Stack) (map[string]string, error) {
+			return cloudformationTagMap(stack.Tags), nil
+		},
+		envelope: func(stack types.Stack) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(stack.StackId),
+				ID:        aws.ToString(stack.StackId),
+				Name:      aws.ToString(stack.StackName),
+				CreatedAt: stack.CreationTime,
+			}
+		},
+	}.run(ctx, ch, region)
 }
 
 func (api *AwsresqCloudformationAPI) queryCloudformationStackSet(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "cloudformation",
-		Resource: "stack-set",
-	}
+	resourceQuery[awsCloudformationAPI, *cloudformation.ListStackSetsOutput, types.StackSetSummary, types.StackSet]{
+		service:  "cloudformation",
+		resource: "stack-set",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsCloudformationAPI, _ string, token *string) (*cloudformation.ListStackSetsOutput, error) {
+			return client.ListStackSets(ctx, &cloudformation.ListStackSetsInput{
+				Status:    types.StackSetStatus(api.opt.Filters.value("status")),
+				NextToken: token,
+			})
+		},
+		// the stack set is described by its ID, which follows the type in its ARN
+		lookup: func(_ context.Context, _ awsCloudformationAPI, target ResourceARN) (string, *cloudformation.ListStackSetsOutput, error) {
+			return "", &cloudformation.ListStackSetsOutput{
+				Summaries: []types.StackSetSummary{{StackSetName: aws.String(target.ID)}},
+			}, nil
+		},
+		items: func(output *cloudformation.ListStackSetsOutput) ([]types.StackSetSummary, *string) {
+			return output.Summaries, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsCloudformationAPI, _ string, summaries []types.StackSetSummary) ([]types.StackSet, error) {
+			output, err := client.DescribeStackSet(ctx, &cloudformation.DescribeStackSetInput{
+				StackSetName: summaries[0].StackSetName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []types.StackSet{*output.StackSet}, nil
+		},
+		batchSize: 1,
+		tags: func(_ context.Context, _ awsCloudformationAPI, stackSet types.StackSet) (map[string]string, error) {
+			return cloudformationTagMap(stackSet.Tags), nil
+		},
+		envelope: func(stackSet types.StackSet) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(stackSet.StackSetARN),
+				ID:   aws.ToString(stackSet.StackSetId),
+				Name: aws.ToString(stackSet.StackSetName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = cloudformation.NewFromConfig(api.awsCfg, func(o *cloudformation.Options) {
-			o.Region = region
-		})
+// listStacksByStatus lists the stacks in the statuses with ListStacks, the only API filtering stacks by status,
+// and describes each of them so that the page is the same as that of DescribeStacks.
+// Deleted stacks can also be described by their stack ID.
+func listStacksByStatus(ctx context.Context, client awsCloudformationAPI, statuses []string, token *string) (*cloudformation.DescribeStacksOutput, error) {
+	filter := make([]types.StackStatus, 0, len(statuses))
+	for _, status := range statuses {
+		filter = append(filter, types.StackStatus(status))
 	}
-
-	listOutput, err := api.apiClient[region].ListStackSets(ctx, nil)
+	output, err := client.ListStacks(ctx, &cloudformation.ListStacksInput{
+		StackStatusFilter: filter,
+		NextToken:         token,
+	})
 	if err != nil {
-		log.Error().Err(err).Msgf("error querying cloudformation stack-sets for region %s", region)
-		return
+		return nil, err
 	}
 
-	for _, stackSet := range listOutput.Summaries {
-		describeOutput, err := api.apiClient[region].DescribeStackSet(ctx, &cloudformation.DescribeStackSetInput{
-			StackSetName: stackSet.StackSetName,
+	var stacks []types.Stack
+	for _, summary := range output.StackSummaries {
+		described, err := client.DescribeStacks(ctx, &cloudformation.DescribeStacksInput{
+			StackName: summary.StackId,
 		})
 		if err != nil {
-			log.Error().Err(err).Msgf("error describing cloudformation stack-set %s for region %s", *stackSet.StackSetName, region)
-			continue
+			return nil, err
 		}
-
-		resultList.Results = append(resultList.Results, *describeOutput.StackSet)
+		stacks = append(stacks, described.Stacks...)
 	}
+	return &cloudformation.DescribeStacksOutput{
+		Stacks:    stacks,
+		NextToken: output.NextToken,
+	}, nil
+}
 
-	ch <- resultList
+func cloudformationTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
@@ -57,7 +57,7 @@ func TestCloudformationStackQuery(t *testing.T) {
 	mc := mock_service.NewMockawsCloudformationAPI(ctrl)
 
 	mc.EXPECT().
-		DescribeStacks(gomock.Any(), nil).
+		DescribeStacks(gomock.Any(), &cloudformation.DescribeStacksInput{}).
 		Return(&cloudformation.DescribeStacksOutput{
 			Stacks: []types.Stack{
 				{
@@ -66,6 +66,21 @@ func TestCloudformationStackQuery(t *testing.T) {
 					StackStatus: types.StackStatusCreateComplete,
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeStacks(gomock.Any(), &cloudformation.DescribeStacksInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []types.Stack{
+				{
+					StackId:     aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/another-stack/guid"),
+					StackName:   aws.String("another-stack"),
+					StackStatus: types.StackStatusUpdateComplete,
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -83,6 +98,11 @@ func TestCloudformationStackQuery(t *testing.T) {
 					StackName:   aws.String("stack-name"),
 					StackStatus: types.StackStatusCreateComplete,
 				},
+				{
+					StackId:     aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/another-stack/guid"),
+					StackName:   aws.String("another-stack"),
+					StackStatus: types.StackStatusUpdateComplete,
+				},
 			},
 			wantErr: false,
 		},
@@ -91,8 +111,8 @@ func TestCloudformationStackQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqCloudformationAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqCloudformationAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "cloudformation", mc)
 
 			actual, err := api.Query("stack")
 
@@ -143,7 +163,7 @@ func TestCloudformationStackSetQuery(t *testing.T) {
 	mc := mock_service.NewMockawsCloudformationAPI(ctrl)
 
 	mc.EXPECT().
-		ListStackSets(gomock.Any(), nil).
+		ListStackSets(gomock.Any(), &cloudformation.ListStackSetsInput{}).
 		Return(&cloudformation.ListStackSetsOutput{
 			Summaries: []types.StackSetSummary{
 				{
@@ -187,8 +207,8 @@ func TestCloudformationStackSetQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqCloudformationAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqCloudformationAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "cloudformation", mc)
 
 			actual, err := api.Query("stack-set")
 
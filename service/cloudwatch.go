@@ -3,95 +3,92 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 )
 
 type awsCloudwatchAPI interface {
 	ListMetrics(ctx context.Context, params *cloudwatch.ListMetricsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListMetricsOutput, error)
 }
 
+var cloudwatchService = register(ServiceDefinition{
+	Name:        "cloudwatch",
+	Description: "Amazon CloudWatch",
+	Resources: []ResourceDefinition{
+		newResource("metric", "CloudWatch metrics", []string{"Namespace", "MetricName", "Dimensions"},
+			func(api *AwsresqCloudwatchAPI) ResourceQueryAPI { return api.queryCloudwatchMetric }),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqCloudwatchAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqCloudwatchAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsCloudwatchAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqCloudwatchAPI(c aws.Config, region []string) *AwsresqCloudwatchAPI {
+func NewAwsresqCloudwatchAPI(c aws.Config, region []string, opt QueryOption) *AwsresqCloudwatchAPI {
 	return &AwsresqCloudwatchAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsCloudwatchAPI, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqCloudwatchAPI) Validate(resource string) bool {
-	validResource := []string{
-		"metric",
-	}
-	return slices.Contains(validResource, resource)
+func (api *AwsresqCloudwatchAPI) Validate(resource string) bool {
+	return cloudwatchService.Validate(resource)
 }
 
-func (api AwsresqCloudwatchAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "cloudwatch",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "metric":
-		apiQuery = api.queryCloudwatchMetric
-	default:
-		return nil, fmt.Errorf("resource %s is not supported in cloudwatch service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, region := range api.region {
-		go apiQuery(ctx, ch, region)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqCloudwatchAPI) DefaultColumns(resource string) []string {
+	return cloudwatchService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqCloudwatchAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqCloudwatchAPI) queryCloudwatchMetric(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "cloudwatch",
-		Resource: "metric",
-	}
+func (api *AwsresqCloudwatchAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return cloudwatchService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = cloudwatch.NewFromConfig(api.awsCfg, func(o *cloudwatch.Options) {
-			o.Region = r
+func (api *AwsresqCloudwatchAPI) client(region string) awsCloudwatchAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "cloudwatch", func() awsCloudwatchAPI {
+		return cloudwatch.NewFromConfig(api.awsCfg, func(o *cloudwatch.Options) {
+			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[r].ListMetrics(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list metrics in region %s", r)
-		return
-	}
-
-	for _, metric := range listOutput.Metrics {
-		resultList.Results = append(resultList.Results, metric)
-	}
+	})
+}
 
-	ch <- resultList
+func (api *AwsresqCloudwatchAPI) queryCloudwatchMetric(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsCloudwatchAPI, *cloudwatch.ListMetricsOutput, types.Metric, types.Metric]{
+		service:  "cloudwatch",
+		resource: "metric",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsCloudwatchAPI, _ string, token *string) (*cloudwatch.ListMetricsOutput, error) {
+			return client.ListMetrics(ctx, &cloudwatch.ListMetricsInput{NextToken: token})
+		},
+		items: func(output *cloudwatch.ListMetricsOutput) ([]types.Metric, *string) {
+			return output.Metrics, output.NextToken
+		},
+		// metrics cannot be tagged
+		tags: untagged[awsCloudwatchAPI, types.Metric],
+		// metrics have no ARN and are identified by their namespace, name and dimensions
+		envelope: func(metric types.Metric) Envelope {
+			id := aws.ToString(metric.Namespace) + "/" + aws.ToString(metric.MetricName)
+			for _, d := range metric.Dimensions {
+				id += "/" + aws.ToString(d.Name) + "=" + aws.ToString(d.Value)
+			}
+			return Envelope{
+				ID:   id,
+				Name: aws.ToString(metric.MetricName),
+			}
+		},
+	}.run(ctx, ch, region)
 }
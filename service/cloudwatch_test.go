@@ -45,7 +45,7 @@ func TestCloudwatchMetricQuery(t *testing.T) {
 	mc := mock_service.NewMockawsCloudwatchAPI(ctrl)
 
 	mc.EXPECT().
-		ListMetrics(gomock.Any(), nil).
+		ListMetrics(gomock.Any(), &cloudwatch.ListMetricsInput{}).
 		Return(&cloudwatch.ListMetricsOutput{
 			Metrics: []types.Metric{
 				{
@@ -53,6 +53,20 @@ func TestCloudwatchMetricQuery(t *testing.T) {
 					Namespace:  aws.String("AWS/ECS"),
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListMetrics(gomock.Any(), &cloudwatch.ListMetricsInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&cloudwatch.ListMetricsOutput{
+			Metrics: []types.Metric{
+				{
+					MetricName: aws.String("MemoryUtilization"),
+					Namespace:  aws.String("AWS/ECS"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -69,6 +83,10 @@ func TestCloudwatchMetricQuery(t *testing.T) {
 					MetricName: aws.String("CPUUtilization"),
 					Namespace:  aws.String("AWS/ECS"),
 				},
+				{
+					MetricName: aws.String("MemoryUtilization"),
+					Namespace:  aws.String("AWS/ECS"),
+				},
 			},
 			wantErr: false,
 		},
@@ -77,8 +95,8 @@ func TestCloudwatchMetricQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqCloudwatchAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqCloudwatchAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "cloudwatch", mc)
 
 			actual, err := api.Query("metric")
 
@@ -3,95 +3,101 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/configservice"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/configservice/types"
 )
 
 type awsConfigAPI interface {
 	DescribeConfigRules(ctx context.Context, params *configservice.DescribeConfigRulesInput, optFns ...func(*configservice.Options)) (*configservice.DescribeConfigRulesOutput, error)
+	ListTagsForResource(ctx context.Context, params *configservice.ListTagsForResourceInput, optFns ...func(*configservice.Options)) (*configservice.ListTagsForResourceOutput, error)
 }
 
+var configService = register(ServiceDefinition{
+	Name:        "config",
+	Aliases:     []string{"configservice"},
+	Description: "AWS Config",
+	Resources: []ResourceDefinition{
+		newResource("rule", "AWS Config rules", []string{"ConfigRuleName", "ConfigRuleState", "Source.Owner", "Source.SourceIdentifier"},
+			func(api *AwsresqConfigAPI) ResourceQueryAPI { return api.queryConfigRule }).withARN("config-rule"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqConfigAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqConfigAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsConfigAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqConfigAPI(awsCfg aws.Config, region []string) *AwsresqConfigAPI {
+func NewAwsresqConfigAPI(awsCfg aws.Config, region []string, opt QueryOption) *AwsresqConfigAPI {
 	return &AwsresqConfigAPI{
-		awsCfg:    awsCfg,
-		region:    region,
-		apiClient: make(map[string]awsConfigAPI, len(region)),
+		awsCfg:  awsCfg,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqConfigAPI) Validate(resource string) bool {
-	validResource := []string{
-		"rule",
-	}
-
-	return slices.Contains(validResource, resource)
+func (api *AwsresqConfigAPI) Validate(resource string) bool {
+	return configService.Validate(resource)
 }
 
-func (api AwsresqConfigAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "config",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "rule":
-		apiQuery = api.queryConfigRule
-	default:
-		return nil, fmt.Errorf("invalid resource type: %s", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, region := range api.region {
-		go apiQuery(ctx, ch, region)
-	}
-
-	for range api.region {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		}
-	}
+func (api *AwsresqConfigAPI) DefaultColumns(resource string) []string {
+	return configService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqConfigAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api AwsresqConfigAPI) queryConfigRule(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "config",
-		Resource: "rule",
-	}
+func (api *AwsresqConfigAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return configService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = configservice.NewFromConfig(api.awsCfg, func(o *configservice.Options) {
+func (api *AwsresqConfigAPI) client(region string) awsConfigAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "config", func() awsConfigAPI {
+		return configservice.NewFromConfig(api.awsCfg, func(o *configservice.Options) {
 			o.Region = region
 		})
-	}
+	})
+}
 
-	listOutput, err := api.apiClient[region].DescribeConfigRules(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to describe config rules")
-		return
-	}
-	for _, rule := range listOutput.ConfigRules {
-		resultList.Results = append(resultList.Results, rule)
-	}
+func (api *AwsresqConfigAPI) queryConfigRule(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsConfigAPI, *configservice.DescribeConfigRulesOutput, types.ConfigRule, types.ConfigRule]{
+		service:  "config",
+		resource: "rule",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsConfigAPI, _ string, token *string) (*configservice.DescribeConfigRulesOutput, error) {
+			return client.DescribeConfigRules(ctx, &configservice.DescribeConfigRulesInput{NextToken: token})
+		},
+		items: func(output *configservice.DescribeConfigRulesOutput) ([]types.ConfigRule, *string) {
+			return output.ConfigRules, output.NextToken
+		},
+		tags: func(ctx context.Context, client awsConfigAPI, rule types.ConfigRule) (map[string]string, error) {
+			output, err := client.ListTagsForResource(ctx, &configservice.ListTagsForResourceInput{
+				ResourceArn: rule.ConfigRuleArn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return configTagMap(output.Tags), nil
+		},
+		envelope: func(rule types.ConfigRule) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(rule.ConfigRuleArn),
+				ID:   aws.ToString(rule.ConfigRuleId),
+				Name: aws.ToString(rule.ConfigRuleName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func configTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
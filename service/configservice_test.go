@@ -51,7 +51,7 @@ func TestConfigRuleQuery(t *testing.T) {
 	mc := mock_service.NewMockawsConfigAPI(ctrl)
 
 	mc.EXPECT().
-		DescribeConfigRules(gomock.Any(), nil).
+		DescribeConfigRules(gomock.Any(), &configservice.DescribeConfigRulesInput{}).
 		Return(&configservice.DescribeConfigRulesOutput{
 			ConfigRules: []types.ConfigRule{
 				{
@@ -60,6 +60,21 @@ func TestConfigRuleQuery(t *testing.T) {
 					ConfigRuleName: aws.String("config-rule-123456"),
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeConfigRules(gomock.Any(), &configservice.DescribeConfigRulesInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&configservice.DescribeConfigRulesOutput{
+			ConfigRules: []types.ConfigRule{
+				{
+					ConfigRuleArn:  aws.String("arn:aws:config:ap-northeast-1:123456789012:config-rule/config-rule-654321"),
+					ConfigRuleId:   aws.String("config-rule-654321"),
+					ConfigRuleName: aws.String("config-rule-654321"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -77,6 +92,11 @@ func TestConfigRuleQuery(t *testing.T) {
 					ConfigRuleId:   aws.String("config-rule-123456"),
 					ConfigRuleName: aws.String("config-rule-123456"),
 				},
+				{
+					ConfigRuleArn:  aws.String("arn:aws:config:ap-northeast-1:123456789012:config-rule/config-rule-654321"),
+					ConfigRuleId:   aws.String("config-rule-654321"),
+					ConfigRuleName: aws.String("config-rule-654321"),
+				},
 			},
 			wantErr: false,
 		},
@@ -85,8 +105,8 @@ func TestConfigRuleQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqConfigAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqConfigAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "config", mc)
 
 			actual, err := api.Query("rule")
 
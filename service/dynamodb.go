@@ -0,0 +1,311 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type awsDynamodbAPI interface {
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	ListBackups(ctx context.Context, params *dynamodb.ListBackupsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListBackupsOutput, error)
+	DescribeGlobalTable(ctx context.Context, params *dynamodb.DescribeGlobalTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeGlobalTableOutput, error)
+	ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)
+}
+
+var dynamodbService = register(ServiceDefinition{
+	Name:        "dynamodb",
+	Description: "Amazon DynamoDB",
+	Resources: []ResourceDefinition{
+		newResource("table", "DynamoDB tables with their time to live settings", []string{"TableName", "TableStatus", "BillingModeSummary.BillingMode", "ItemCount", "TimeToLiveDescription.TimeToLiveStatus"},
+			func(api *AwsresqDynamodbAPI) ResourceQueryAPI { return api.queryTable }).withARN("table"),
+		newResource("backup", "DynamoDB on-demand backups", []string{"BackupName", "TableName", "BackupType", "BackupStatus", "BackupCreationDateTime"},
+			func(api *AwsresqDynamodbAPI) ResourceQueryAPI { return api.queryBackup }).
+			withARN("table/*/backup").
+			withFilters(singleValueFilters("backup-type", "table-name")...),
+		newResource("global-table", "DynamoDB global tables of both versions", []string{"GlobalTableName", "GlobalTableStatus", "ReplicationGroup.RegionName"},
+			func(api *AwsresqDynamodbAPI) ResourceQueryAPI { return api.queryGlobalTable }).withARN("global-table"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqDynamodbAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqDynamodbAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqDynamodbAPI(c aws.Config, region []string, opt QueryOption) *AwsresqDynamodbAPI {
+	return &AwsresqDynamodbAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqDynamodbAPI) Validate(resource string) bool {
+	return dynamodbService.Validate(resource)
+}
+
+func (api *AwsresqDynamodbAPI) DefaultColumns(resource string) []string {
+	return dynamodbService.DefaultColumns(resource)
+}
+
+func (api *AwsresqDynamodbAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqDynamodbAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return dynamodbService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqDynamodbAPI) client(region string) awsDynamodbAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "dynamodb", func() awsDynamodbAPI {
+		return dynamodb.NewFromConfig(api.awsCfg, func(o *dynamodb.Options) {
+			o.Region = region
+		})
+	})
+}
+
+// dynamodbTable is a table along with its time to live settings, which DescribeTable does not return.
+// The fields of the table are embedded, so that both are rendered as a single object.
+type dynamodbTable struct {
+	*types.TableDescription
+	TimeToLiveDescription *types.TimeToLiveDescription
+}
+
+// dynamodbLegacyGlobalTableVersion is the version of global tables whose replicas are described with DescribeGlobalTable.
+const dynamodbLegacyGlobalTableVersion = "2017.11.29"
+
+// dynamodbGlobalTable is a global table of either version, found from the tables replicated across regions.
+// The replicas of both versions are in ReplicationGroup.
+type dynamodbGlobalTable struct {
+	GlobalTableArn     *string
+	GlobalTableName    *string
+	GlobalTableVersion *string
+	GlobalTableStatus  string
+	ReplicationGroup   []types.ReplicaDescription
+	CreationDateTime   *time.Time
+}
+
+func (api *AwsresqDynamodbAPI) queryTable(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsDynamodbAPI, *dynamodb.ListTablesOutput, string, dynamodbTable]{
+		service:  "dynamodb",
+		resource: "table",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsDynamodbAPI, _ string, token *string) (*dynamodb.ListTablesOutput, error) {
+			return client.ListTables(ctx, &dynamodb.ListTablesInput{ExclusiveStartTableName: token})
+		},
+		lookup: func(_ context.Context, _ awsDynamodbAPI, target ResourceARN) (string, *dynamodb.ListTablesOutput, error) {
+			return "", &dynamodb.ListTablesOutput{TableNames: []string{target.ID}}, nil
+		},
+		items: func(output *dynamodb.ListTablesOutput) ([]string, *string) {
+			return output.TableNames, output.LastEvaluatedTableName
+		},
+		describe: func(ctx context.Context, client awsDynamodbAPI, _ string, names []string) ([]dynamodbTable, error) {
+			table, err := dynamodbDescribeTable(ctx, client, names[0])
+			if err != nil {
+				return nil, err
+			}
+			ttl, err := client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+				TableName: aws.String(names[0]),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []dynamodbTable{{
+				TableDescription:      table,
+				TimeToLiveDescription: ttl.TimeToLiveDescription,
+			}}, nil
+		},
+		batchSize: 1,
+		tags: func(ctx context.Context, client awsDynamodbAPI, table dynamodbTable) (map[string]string, error) {
+			return dynamodbListTags(ctx, client, table.TableArn)
+		},
+		envelope: func(table dynamodbTable) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(table.TableArn),
+				ID:        aws.ToString(table.TableName),
+				Name:      aws.ToString(table.TableName),
+				CreatedAt: table.CreationDateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqDynamodbAPI) queryBackup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsDynamodbAPI, *dynamodb.ListBackupsOutput, types.BackupSummary, types.BackupSummary]{
+		service:  "dynamodb",
+		resource: "backup",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsDynamodbAPI, _ string, token *string) (*dynamodb.ListBackupsOutput, error) {
+			return client.ListBackups(ctx, &dynamodb.ListBackupsInput{
+				BackupType:              types.BackupTypeFilter(api.opt.Filters.value("backup-type")),
+				TableName:               optionalString(api.opt.Filters.value("table-name")),
+				ExclusiveStartBackupArn: token,
+			})
+		},
+		items: func(output *dynamodb.ListBackupsOutput) ([]types.BackupSummary, *string) {
+			return output.BackupSummaries, output.LastEvaluatedBackupArn
+		},
+		envelope: func(backup types.BackupSummary) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(backup.BackupArn),
+				ID:        aws.ToString(backup.BackupArn),
+				Name:      aws.ToString(backup.BackupName),
+				CreatedAt: backup.BackupCreationDateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqDynamodbAPI) queryGlobalTable(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsDynamodbAPI, *dynamodb.ListTablesOutput, string, dynamodbGlobalTable]{
+		service:  "dynamodb",
+		resource: "global-table",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsDynamodbAPI, _ string, token *string) (*dynamodb.ListTablesOutput, error) {
+			return client.ListTables(ctx, &dynamodb.ListTablesInput{ExclusiveStartTableName: token})
+		},
+		lookup: func(_ context.Context, _ awsDynamodbAPI, target ResourceARN) (string, *dynamodb.ListTablesOutput, error) {
+			return "", &dynamodb.ListTablesOutput{TableNames: []string{target.ID}}, nil
+		},
+		items: func(output *dynamodb.ListTablesOutput) ([]string, *string) {
+			return output.TableNames, output.LastEvaluatedTableName
+		},
+		describe: func(ctx context.Context, client awsDynamodbAPI, _ string, names []string) ([]dynamodbGlobalTable, error) {
+			table, err := dynamodbDescribeTable(ctx, client, names[0])
+			var notFound *types.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				// the ARNs of global tables have no region, so they are looked up in every region searched,
+				// including the regions without a replica of the table
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if table.GlobalTableVersion == nil {
+				return nil, nil
+			}
+
+			globalTable := dynamodbGlobalTable{
+				GlobalTableArn:     dynamodbGlobalTableARN(aws.ToString(table.TableArn), aws.ToString(table.TableName)),
+				GlobalTableName:    table.TableName,
+				GlobalTableVersion: table.GlobalTableVersion,
+				GlobalTableStatus:  string(table.TableStatus),
+				ReplicationGroup:   table.Replicas,
+				CreationDateTime:   table.CreationDateTime,
+			}
+			// DescribeTable returns the replicas of the current version only
+			if aws.ToString(table.GlobalTableVersion) == dynamodbLegacyGlobalTableVersion {
+				output, err := client.DescribeGlobalTable(ctx, &dynamodb.DescribeGlobalTableInput{
+					GlobalTableName: table.TableName,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if description := output.GlobalTableDescription; description != nil {
+					globalTable.GlobalTableArn = description.GlobalTableArn
+					globalTable.GlobalTableStatus = string(description.GlobalTableStatus)
+					globalTable.ReplicationGroup = description.ReplicationGroup
+					globalTable.CreationDateTime = description.CreationDateTime
+				}
+			}
+
+			if api.globalTableRegion(globalTable, region) != region {
+				return nil, nil
+			}
+			return []dynamodbGlobalTable{globalTable}, nil
+		},
+		batchSize: 1,
+		envelope: func(table dynamodbGlobalTable) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(table.GlobalTableArn),
+				ID:        aws.ToString(table.GlobalTableName),
+				Name:      aws.ToString(table.GlobalTableName),
+				CreatedAt: table.CreationDateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+// globalTableRegion returns the region the global table is reported from, which is the first of the queried regions
+// having a replica of it. Every replica region lists the table, and it would be reported from each otherwise.
+func (api *AwsresqDynamodbAPI) globalTableRegion(table dynamodbGlobalTable, region string) string {
+	replicas := map[string]bool{region: true}
+	for _, replica := range table.ReplicationGroup {
+		replicas[aws.ToString(replica.RegionName)] = true
+	}
+	for _, r := range api.region {
+		if replicas[r] {
+			return r
+		}
+	}
+	return region
+}
+
+// dynamodbGlobalTableARN returns the ARN of the global table of the table, which has no region.
+func dynamodbGlobalTableARN(tableARN, name string) *string {
+	a, err := arn.Parse(tableARN)
+	if err != nil {
+		return nil
+	}
+	a.Region = ""
+	a.Resource = "global-table/" + name
+	return aws.String(a.String())
+}
+
+// dynamodbDescribeTable returns the description of the table, which the results of tables embed and so must not be nil.
+func dynamodbDescribeTable(ctx context.Context, client awsDynamodbAPI, name string) (*types.TableDescription, error) {
+	output, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Table == nil {
+		return nil, fmt.Errorf("no description of table returned: '%s'", name)
+	}
+	return output.Table, nil
+}
+
+// dynamodbListTags returns the tags of the resource, following every page of ListTagsOfResource.
+func dynamodbListTags(ctx context.Context, client awsDynamodbAPI, arn *string) (map[string]string, error) {
+	var tags []types.Tag
+	var token *string
+	for {
+		output, err := client.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: arn,
+			NextToken:   token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, output.Tags...)
+		if output.NextToken == nil {
+			break
+		}
+		token = output.NextToken
+	}
+	return dynamodbTagMap(tags), nil
+}
+
+func dynamodbTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
+}
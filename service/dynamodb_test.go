@@ -0,0 +1,570 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestDynamodbValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqDynamodbAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate table resource",
+			api:      AwsresqDynamodbAPI{},
+			resource: "table",
+			expected: true,
+		},
+		{
+			name:     "validate backup resource",
+			api:      AwsresqDynamodbAPI{},
+			resource: "backup",
+			expected: true,
+		},
+		{
+			name:     "validate global-table resource",
+			api:      AwsresqDynamodbAPI{},
+			resource: "global-table",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqDynamodbAPI{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDynamodbTableQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsDynamodbAPI(ctrl)
+
+	mc.EXPECT().
+		ListTables(gomock.Any(), &dynamodb.ListTablesInput{}).
+		Return(&dynamodb.ListTablesOutput{
+			TableNames:             []string{"orders"},
+			LastEvaluatedTableName: aws.String("orders"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTables(gomock.Any(), &dynamodb.ListTablesInput{
+			ExclusiveStartTableName: aws.String("orders"),
+		}).
+		Return(&dynamodb.ListTablesOutput{
+			TableNames: []string{"sessions"},
+		}, nil).
+		AnyTimes()
+	for _, table := range []*types.TableDescription{
+		{
+			TableArn:           aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders"),
+			TableName:          aws.String("orders"),
+			BillingModeSummary: &types.BillingModeSummary{BillingMode: types.BillingModePayPerRequest},
+			StreamSpecification: &types.StreamSpecification{
+				StreamEnabled:  aws.Bool(true),
+				StreamViewType: types.StreamViewTypeNewAndOldImages,
+			},
+		},
+		{
+			TableArn:  aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/sessions"),
+			TableName: aws.String("sessions"),
+			GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{
+				{IndexName: aws.String("user-index")},
+			},
+		},
+	} {
+		mc.EXPECT().
+			DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: table.TableName}).
+			Return(&dynamodb.DescribeTableOutput{Table: table}, nil).
+			AnyTimes()
+	}
+	mc.EXPECT().
+		DescribeTimeToLive(gomock.Any(), &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("orders")}).
+		Return(&dynamodb.DescribeTimeToLiveOutput{
+			TimeToLiveDescription: &types.TimeToLiveDescription{TimeToLiveStatus: types.TimeToLiveStatusDisabled},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeTimeToLive(gomock.Any(), &dynamodb.DescribeTimeToLiveInput{TableName: aws.String("sessions")}).
+		Return(&dynamodb.DescribeTimeToLiveOutput{
+			TimeToLiveDescription: &types.TimeToLiveDescription{
+				AttributeName:    aws.String("expiresAt"),
+				TimeToLiveStatus: types.TimeToLiveStatusEnabled,
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTagsOfResource(gomock.Any(), &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders"),
+		}).
+		Return(&dynamodb.ListTagsOfResourceOutput{
+			Tags:      []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTagsOfResource(gomock.Any(), &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders"),
+			NextToken:   aws.String("next-token"),
+		}).
+		Return(&dynamodb.ListTagsOfResourceOutput{
+			Tags: []types.Tag{{Key: aws.String("team"), Value: aws.String("payments")}},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTagsOfResource(gomock.Any(), &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/sessions"),
+		}).
+		Return(&dynamodb.ListTagsOfResourceOutput{}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []string
+		ttl      []types.TimeToLiveStatus
+	}{
+		{
+			name:     "query table resource",
+			expected: []string{"orders", "sessions"},
+			ttl:      []types.TimeToLiveStatus{types.TimeToLiveStatusDisabled, types.TimeToLiveStatusEnabled},
+		},
+		{
+			name:     "query table resource with tags of every page",
+			opt:      QueryOption{TagFilters: TagFilters{{Key: "team", Value: "payments", HasValue: true}}},
+			expected: []string{"orders"},
+			ttl:      []types.TimeToLiveStatus{types.TimeToLiveStatusDisabled},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqDynamodbAPI(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put("", "ap-northeast-1", "dynamodb", mc)
+
+			actual, err := api.Query("table")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if actual.Service != "dynamodb" {
+				t.Errorf("expected service 'dynamodb', but got '%v'", actual.Service)
+			}
+			if actual.Resource != "table" {
+				t.Errorf("expected resource 'table', but got '%v'", actual.Resource)
+			}
+
+			if len(tt.expected) != len(actual.Results) {
+				t.Fatalf("expected %d results, but got %d", len(tt.expected), len(actual.Results))
+			}
+			for i := range tt.expected {
+				actualOutput, ok := actual.Results[i].(dynamodbTable)
+				if !ok {
+					t.Fatalf("expected dynamodbTable, but got %T", actual.Results[i])
+				}
+				if aws.ToString(actualOutput.TableName) != tt.expected[i] {
+					t.Errorf("expected %v, but got %v", tt.expected[i], aws.ToString(actualOutput.TableName))
+				}
+				if actualOutput.TimeToLiveDescription.TimeToLiveStatus != tt.ttl[i] {
+					t.Errorf("expected %v, but got %v", tt.ttl[i], actualOutput.TimeToLiveDescription.TimeToLiveStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestDynamodbTableQueryWithoutDescription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsDynamodbAPI(ctrl)
+
+	mc.EXPECT().
+		ListTables(gomock.Any(), &dynamodb.ListTablesInput{}).
+		Return(&dynamodb.ListTablesOutput{TableNames: []string{"orders"}}, nil).
+		Times(1)
+	mc.EXPECT().
+		DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("orders")}).
+		Return(&dynamodb.DescribeTableOutput{}, nil).
+		Times(1)
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqDynamodbAPI(config, []string{"ap-northeast-1"}, QueryOption{Envelope: true})
+	api.clients.put("", "ap-northeast-1", "dynamodb", mc)
+
+	actual, err := api.Query("table")
+
+	if !errors.Is(err, ErrQueryFailed) {
+		t.Errorf("expected %v, but got %v", ErrQueryFailed, err)
+	}
+	if len(actual.Results) != 0 {
+		t.Errorf("expected no results, but got %+v", actual.Results)
+	}
+	if len(actual.Errors) != 1 || !strings.Contains(actual.Errors[0].Message, "no description of table returned: 'orders'") {
+		t.Errorf("expected an error of the table without description, but got %+v", actual.Errors)
+	}
+}
+
+func TestDynamodbBackupQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsDynamodbAPI(ctrl)
+
+	mc.EXPECT().
+		ListBackups(gomock.Any(), &dynamodb.ListBackupsInput{}).
+		Return(&dynamodb.ListBackupsOutput{
+			BackupSummaries: []types.BackupSummary{
+				{
+					BackupArn:  aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders/backup/01234567890123-abcdefgh"),
+					BackupName: aws.String("orders-backup"),
+					TableName:  aws.String("orders"),
+					BackupType: types.BackupTypeUser,
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListBackups(gomock.Any(), &dynamodb.ListBackupsInput{
+			BackupType: types.BackupTypeFilterAll,
+			TableName:  aws.String("sessions"),
+		}).
+		Return(&dynamodb.ListBackupsOutput{
+			BackupSummaries: []types.BackupSummary{
+				{
+					BackupArn:  aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/sessions/backup/01234567890123-ijklmnop"),
+					BackupName: aws.String("sessions-backup"),
+					TableName:  aws.String("sessions"),
+					BackupType: types.BackupTypeAwsBackup,
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		filters  Filters
+		expected []interface{}
+	}{
+		{
+			name: "query backup resource",
+			expected: []interface{}{
+				types.BackupSummary{
+					BackupArn:  aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/orders/backup/01234567890123-abcdefgh"),
+					BackupName: aws.String("orders-backup"),
+					TableName:  aws.String("orders"),
+					BackupType: types.BackupTypeUser,
+				},
+			},
+		},
+		{
+			name: "query backup resource with filters",
+			filters: Filters{
+				{Name: "backup-type", Values: []string{"ALL"}},
+				{Name: "table-name", Values: []string{"sessions"}},
+			},
+			expected: []interface{}{
+				types.BackupSummary{
+					BackupArn:  aws.String("arn:aws:dynamodb:ap-northeast-1:012345678901:table/sessions/backup/01234567890123-ijklmnop"),
+					BackupName: aws.String("sessions-backup"),
+					TableName:  aws.String("sessions"),
+					BackupType: types.BackupTypeAwsBackup,
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqDynamodbAPI(config, []string{"ap-northeast-1"}, QueryOption{Filters: tt.filters})
+			api.clients.put("", "ap-northeast-1", "dynamodb", mc)
+
+			actual, err := api.Query("backup")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestDynamodbGlobalTableQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	replicas := []types.ReplicaDescription{
+		{RegionName: aws.String("ap-northeast-1")},
+		{RegionName: aws.String("us-east-1")},
+	}
+	clients := map[string]*mock_service.MockawsDynamodbAPI{}
+	for _, region := range []string{"ap-northeast-1", "us-east-1"} {
+		mc := mock_service.NewMockawsDynamodbAPI(ctrl)
+		clients[region] = mc
+
+		mc.EXPECT().
+			ListTables(gomock.Any(), &dynamodb.ListTablesInput{}).
+			Return(&dynamodb.ListTablesOutput{TableNames: []string{"local", "orders", "sessions"}}, nil).
+			AnyTimes()
+		mc.EXPECT().
+			DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("local")}).
+			Return(&dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{
+					TableArn:  aws.String("arn:aws:dynamodb:" + region + ":012345678901:table/local"),
+					TableName: aws.String("local"),
+				},
+			}, nil).
+			AnyTimes()
+		mc.EXPECT().
+			DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("orders")}).
+			Return(&dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{
+					TableArn:           aws.String("arn:aws:dynamodb:" + region + ":012345678901:table/orders"),
+					TableName:          aws.String("orders"),
+					TableStatus:        types.TableStatusActive,
+					GlobalTableVersion: aws.String("2019.11.21"),
+					Replicas:           replicas,
+				},
+			}, nil).
+			AnyTimes()
+		mc.EXPECT().
+			DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("sessions")}).
+			Return(&dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{
+					TableArn:           aws.String("arn:aws:dynamodb:" + region + ":012345678901:table/sessions"),
+					TableName:          aws.String("sessions"),
+					TableStatus:        types.TableStatusActive,
+					GlobalTableVersion: aws.String("2017.11.29"),
+				},
+			}, nil).
+			AnyTimes()
+		mc.EXPECT().
+			DescribeGlobalTable(gomock.Any(), &dynamodb.DescribeGlobalTableInput{GlobalTableName: aws.String("sessions")}).
+			Return(&dynamodb.DescribeGlobalTableOutput{
+				GlobalTableDescription: &types.GlobalTableDescription{
+					GlobalTableArn:    aws.String("arn:aws:dynamodb::012345678901:global-table/sessions"),
+					GlobalTableName:   aws.String("sessions"),
+					GlobalTableStatus: types.GlobalTableStatusActive,
+					ReplicationGroup:  replicas,
+				},
+			}, nil).
+			AnyTimes()
+	}
+
+	mcOther := mock_service.NewMockawsDynamodbAPI(ctrl)
+	clients["eu-west-1"] = mcOther
+	mcOther.EXPECT().
+		DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("orders")}).
+		Return(nil, &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}).
+		AnyTimes()
+
+	// archive has no replica in us-east-1, the default region of the partition
+	archiveReplicas := []types.ReplicaDescription{
+		{RegionName: aws.String("ap-northeast-1")},
+		{RegionName: aws.String("eu-west-1")},
+	}
+	for _, region := range []string{"ap-northeast-1", "eu-west-1"} {
+		clients[region].EXPECT().
+			DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("archive")}).
+			Return(&dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{
+					TableArn:           aws.String("arn:aws:dynamodb:" + region + ":012345678901:table/archive"),
+					TableName:          aws.String("archive"),
+					TableStatus:        types.TableStatusActive,
+					GlobalTableVersion: aws.String("2019.11.21"),
+					Replicas:           archiveReplicas,
+				},
+			}, nil).
+			AnyTimes()
+	}
+	clients["us-east-1"].EXPECT().
+		DescribeTable(gomock.Any(), &dynamodb.DescribeTableInput{TableName: aws.String("archive")}).
+		Return(nil, &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}).
+		AnyTimes()
+
+	ordersARN, err := ParseResourceARN("arn:aws:dynamodb::012345678901:global-table/orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	archiveARN, err := ParseResourceARN("arn:aws:dynamodb::012345678901:global-table/archive")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		region   []string
+		arn      *ResourceARN
+		expected []interface{}
+	}{
+		{
+			name:   "query global-table resource of both versions once in replica regions",
+			region: []string{"ap-northeast-1", "us-east-1"},
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/orders",
+					ID:       "orders",
+					Name:     "orders",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/orders"),
+						GlobalTableName:    aws.String("orders"),
+						GlobalTableVersion: aws.String("2019.11.21"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   replicas,
+					},
+				},
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/sessions",
+					ID:       "sessions",
+					Name:     "sessions",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/sessions"),
+						GlobalTableName:    aws.String("sessions"),
+						GlobalTableVersion: aws.String("2017.11.29"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   replicas,
+					},
+				},
+			},
+		},
+		{
+			name:   "query global-table resource in a replica region other than the first",
+			region: []string{"us-east-1"},
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/orders",
+					ID:       "orders",
+					Name:     "orders",
+					Region:   "us-east-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/orders"),
+						GlobalTableName:    aws.String("orders"),
+						GlobalTableVersion: aws.String("2019.11.21"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   replicas,
+					},
+				},
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/sessions",
+					ID:       "sessions",
+					Name:     "sessions",
+					Region:   "us-east-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/sessions"),
+						GlobalTableName:    aws.String("sessions"),
+						GlobalTableVersion: aws.String("2017.11.29"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   replicas,
+					},
+				},
+			},
+		},
+		{
+			name:   "look up global-table resource by arn without region",
+			region: []string{"eu-west-1", "us-east-1"},
+			arn:    &ordersARN,
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/orders",
+					ID:       "orders",
+					Name:     "orders",
+					Region:   "us-east-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/orders"),
+						GlobalTableName:    aws.String("orders"),
+						GlobalTableVersion: aws.String("2019.11.21"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   replicas,
+					},
+				},
+			},
+		},
+		{
+			name:   "look up global-table resource by arn without a replica in the default region",
+			region: []string{"ap-northeast-1", "eu-west-1", "us-east-1"},
+			arn:    &archiveARN,
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:dynamodb::012345678901:global-table/archive",
+					ID:       "archive",
+					Name:     "archive",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "dynamodb",
+					Resource: "global-table",
+					Tags:     map[string]string{},
+					Raw: dynamodbGlobalTable{
+						GlobalTableArn:     aws.String("arn:aws:dynamodb::012345678901:global-table/archive"),
+						GlobalTableName:    aws.String("archive"),
+						GlobalTableVersion: aws.String("2019.11.21"),
+						GlobalTableStatus:  "ACTIVE",
+						ReplicationGroup:   archiveReplicas,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqDynamodbAPI(config, tt.region, QueryOption{ARN: tt.arn, Envelope: true})
+			for region, mc := range clients {
+				api.clients.put("", region, "dynamodb", mc)
+			}
+
+			actual, err := api.Query("global-table")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
@@ -3,13 +3,10 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 type awsEc2API interface {
@@ -18,137 +15,235 @@ type awsEc2API interface {
 	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
 }
 
+var ec2Service = register(ServiceDefinition{
+	Name:        "ec2",
+	Description: "Amazon Elastic Compute Cloud",
+	Resources: []ResourceDefinition{
+		newResource("instance", "EC2 instances", []string{"InstanceId", "InstanceType", "State.Name", "PrivateIpAddress", "Tags.Name"},
+			func(api *AwsresqEc2API) ResourceQueryAPI { return api.queryEc2Instance }).
+			withARN("instance").
+			withFilters(filterDefinition{name: "*"}),
+		newResource("security-group", "VPC security groups", []string{"GroupId", "GroupName", "VpcId", "Description"},
+			func(api *AwsresqEc2API) ResourceQueryAPI { return api.queryEc2SecurityGroup }).
+			withARN("security-group").
+			withFilters(filterDefinition{name: "*"}),
+		newResource("vpc", "VPCs", []string{"VpcId", "CidrBlock", "IsDefault", "Tags.Name"},
+			func(api *AwsresqEc2API) ResourceQueryAPI { return api.queryEc2Vpc }).
+			withARN("vpc").
+			withFilters(filterDefinition{name: "*"}),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqEc2API(cfg, region, opt)
+	},
+})
+
 type AwsresqEc2API struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsEc2API
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqEc2API(c aws.Config, region []string) *AwsresqEc2API {
+func NewAwsresqEc2API(c aws.Config, region []string, opt QueryOption) *AwsresqEc2API {
 	return &AwsresqEc2API{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsEc2API, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqEc2API) Validate(resource string) bool {
-	validResource := []string{
-		"instance",
-		"security-group",
-		"vpc",
-	}
-	return slices.Contains(validResource, resource)
+func (api *AwsresqEc2API) Validate(resource string) bool {
+	return ec2Service.Validate(resource)
 }
 
-func (api AwsresqEc2API) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "ec2",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "instance":
-		apiQuery = api.queryEc2Instance
-	case "security-group":
-		apiQuery = api.queryEc2SecurityGroup
-	case "vpc":
-		apiQuery = api.queryEc2Vpc
-	default:
-		return nil, fmt.Errorf("resource %s is not supported in ec2 service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, region := range api.region {
-		go apiQuery(ctx, ch, region)
-	}
-
-	for range api.region {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		}
-	}
+func (api *AwsresqEc2API) DefaultColumns(resource string) []string {
+	return ec2Service.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqEc2API) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api AwsresqEc2API) queryEc2Instance(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "ec2",
-		Resource: "instance",
-	}
+func (api *AwsresqEc2API) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return ec2Service.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = ec2.NewFromConfig(api.awsCfg, func(o *ec2.Options) {
+func (api *AwsresqEc2API) client(region string) awsEc2API {
+	return cachedClient(api.clients, api.opt.Account, region, "ec2", func() awsEc2API {
+		return ec2.NewFromConfig(api.awsCfg, func(o *ec2.Options) {
 			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[region].DescribeInstances(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to describe ec2 instance in region %s", region)
-		return
-	}
-	for _, reservation := range listOutput.Reservations {
-		for _, instance := range reservation.Instances {
-			resultList.Results = append(resultList.Results, instance)
-		}
-	}
-
-	ch <- resultList
+	})
 }
 
-func (api AwsresqEc2API) queryEc2SecurityGroup(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "ec2",
-		Resource: "security-group",
-	}
+// ec2Instance is an instance along with the owner of its reservation, which is needed to build its ARN.
+type ec2Instance struct {
+	instance types.Instance
+	ownerID  *string
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = ec2.NewFromConfig(api.awsCfg, func(o *ec2.Options) {
-			o.Region = region
-		})
-	}
+func (api *AwsresqEc2API) queryEc2Instance(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEc2API, *ec2.DescribeInstancesOutput, ec2Instance, ec2Instance]{
+		service:  "ec2",
+		resource: "instance",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEc2API, _ string, token *string) (*ec2.DescribeInstancesOutput, error) {
+			return client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+				Filters:   ec2Filters(api.opt.Filters, api.opt.TagFilters),
+				NextToken: token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsEc2API, target ResourceARN) (string, *ec2.DescribeInstancesOutput, error) {
+			output, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+				InstanceIds: []string{target.ID},
+			})
+			return "", output, err
+		},
+		items: func(output *ec2.DescribeInstancesOutput) ([]ec2Instance, *string) {
+			var instances []ec2Instance
+			for _, reservation := range output.Reservations {
+				for _, instance := range reservation.Instances {
+					instances = append(instances, ec2Instance{instance: instance, ownerID: reservation.OwnerId})
+				}
+			}
+			return instances, output.NextToken
+		},
+		tags: func(_ context.Context, _ awsEc2API, instance ec2Instance) (map[string]string, error) {
+			return ec2TagMap(instance.instance.Tags), nil
+		},
+		raw: func(instance ec2Instance) interface{} {
+			return instance.instance
+		},
+		envelope: func(instance ec2Instance) Envelope {
+			id := aws.ToString(instance.instance.InstanceId)
+			return Envelope{
+				ARN:       api.ec2ARN(region, instance.ownerID, "instance/"+id),
+				ID:        id,
+				Name:      ec2TagMap(instance.instance.Tags)["Name"],
+				CreatedAt: instance.instance.LaunchTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	listOutput, err := api.apiClient[region].DescribeSecurityGroups(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to describe ec2 security group in region %s", region)
-		return
-	}
-	for _, securityGroup := range listOutput.SecurityGroups {
-		resultList.Results = append(resultList.Results, securityGroup)
-	}
+func (api *AwsresqEc2API) queryEc2SecurityGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEc2API, *ec2.DescribeSecurityGroupsOutput, types.SecurityGroup, types.SecurityGroup]{
+		service:  "ec2",
+		resource: "security-group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEc2API, _ string, token *string) (*ec2.DescribeSecurityGroupsOutput, error) {
+			return client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+				Filters:   ec2Filters(api.opt.Filters, api.opt.TagFilters),
+				NextToken: token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsEc2API, target ResourceARN) (string, *ec2.DescribeSecurityGroupsOutput, error) {
+			output, err := client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+				GroupIds: []string{target.ID},
+			})
+			return "", output, err
+		},
+		items: func(output *ec2.DescribeSecurityGroupsOutput) ([]types.SecurityGroup, *string) {
+			return output.SecurityGroups, output.NextToken
+		},
+		tags: func(_ context.Context, _ awsEc2API, securityGroup types.SecurityGroup) (map[string]string, error) {
+			return ec2TagMap(securityGroup.Tags), nil
+		},
+		envelope: func(securityGroup types.SecurityGroup) Envelope {
+			id := aws.ToString(securityGroup.GroupId)
+			return Envelope{
+				ARN:  api.ec2ARN(region, securityGroup.OwnerId, "security-group/"+id),
+				ID:   id,
+				Name: aws.ToString(securityGroup.GroupName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func (api *AwsresqEc2API) queryEc2Vpc(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEc2API, *ec2.DescribeVpcsOutput, types.Vpc, types.Vpc]{
+		service:  "ec2",
+		resource: "vpc",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEc2API, _ string, token *string) (*ec2.DescribeVpcsOutput, error) {
+			return client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+				Filters:   ec2Filters(api.opt.Filters, api.opt.TagFilters),
+				NextToken: token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsEc2API, target ResourceARN) (string, *ec2.DescribeVpcsOutput, error) {
+			output, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+				VpcIds: []string{target.ID},
+			})
+			return "", output, err
+		},
+		items: func(output *ec2.DescribeVpcsOutput) ([]types.Vpc, *string) {
+			return output.Vpcs, output.NextToken
+		},
+		tags: func(_ context.Context, _ awsEc2API, vpc types.Vpc) (map[string]string, error) {
+			return ec2TagMap(vpc.Tags), nil
+		},
+		envelope: func(vpc types.Vpc) Envelope {
+			id := aws.ToString(vpc.VpcId)
+			return Envelope{
+				ARN:  api.ec2ARN(region, vpc.OwnerId, "vpc/"+id),
+				ID:   id,
+				Name: ec2TagMap(vpc.Tags)["Name"],
+			}
+		},
+	}.run(ctx, ch, region)
 }
 
-func (api AwsresqEc2API) queryEc2Vpc(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "ec2",
-		Resource: "vpc",
+// ec2ARN builds the ARN of an EC2 resource, which is not returned by the describe APIs.
+// The account of the credentials is used when the owner is unknown.
+func (api *AwsresqEc2API) ec2ARN(region string, ownerID *string, resource string) string {
+	account := aws.ToString(ownerID)
+	if account == "" {
+		account = api.opt.Account
 	}
+	return api.opt.Partition.ARN("ec2", region, account, resource)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = ec2.NewFromConfig(api.awsCfg, func(o *ec2.Options) {
-			o.Region = region
+// ec2Filters translates filters and tag filters into server-side filters.
+// Every filter is passed as is, as the describe APIs of EC2 take the same filters as the AWS CLI.
+func ec2Filters(filters Filters, tagFilters TagFilters) []types.Filter {
+	ec2Filters := ec2TagFilters(tagFilters)
+	for _, f := range filters {
+		ec2Filters = append(ec2Filters, types.Filter{
+			Name:   aws.String(f.Name),
+			Values: f.Values,
 		})
 	}
+	return ec2Filters
+}
 
-	listOutput, err := api.apiClient[region].DescribeVpcs(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to describe ec2 vpc in region %s", region)
-		return
-	}
-	for _, vpc := range listOutput.Vpcs {
-		resultList.Results = append(resultList.Results, vpc)
+// ec2TagFilters translates tag filters into server-side filters.
+// Exclusions cannot be expressed as EC2 filters and are only evaluated on the returned resources.
+func ec2TagFilters(tagFilters TagFilters) []types.Filter {
+	var filters []types.Filter
+	for _, f := range tagFilters {
+		if f.Exclude {
+			continue
+		}
+		if f.HasValue {
+			filters = append(filters, types.Filter{
+				Name:   aws.String("tag:" + f.Key),
+				Values: []string{f.Value},
+			})
+		} else {
+			filters = append(filters, types.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []string{f.Key},
+			})
+		}
 	}
+	return filters
+}
 
-	ch <- resultList
+func ec2TagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
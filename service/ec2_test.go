@@ -51,7 +51,7 @@ func TestEc2InstanceQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEc2API(ctrl)
 
 	mc.EXPECT().
-		DescribeInstances(gomock.Any(), nil).
+		DescribeInstances(gomock.Any(), &ec2.DescribeInstancesInput{}).
 		Return(&ec2.DescribeInstancesOutput{
 			Reservations: []types.Reservation{
 				{
@@ -63,6 +63,24 @@ func TestEc2InstanceQuery(t *testing.T) {
 					},
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeInstances(gomock.Any(), &ec2.DescribeInstancesInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{
+						{
+							InstanceId:   aws.String("i-0fedcba0987654321"),
+							InstanceType: types.InstanceTypeT3Micro,
+						},
+					},
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -79,6 +97,10 @@ func TestEc2InstanceQuery(t *testing.T) {
 					InstanceId:   aws.String("i-1234567890abcdef0"),
 					InstanceType: types.InstanceTypeT2Micro,
 				},
+				{
+					InstanceId:   aws.String("i-0fedcba0987654321"),
+					InstanceType: types.InstanceTypeT3Micro,
+				},
 			},
 			wantErr: false,
 		},
@@ -87,8 +109,8 @@ func TestEc2InstanceQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ec2", mc)
 
 			actual, err := api.Query("instance")
 
@@ -136,7 +158,7 @@ func TestEc2SecurityGroupQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEc2API(ctrl)
 
 	mc.EXPECT().
-		DescribeSecurityGroups(gomock.Any(), nil).
+		DescribeSecurityGroups(gomock.Any(), &ec2.DescribeSecurityGroupsInput{}).
 		Return(&ec2.DescribeSecurityGroupsOutput{
 			SecurityGroups: []types.SecurityGroup{
 				{
@@ -168,8 +190,8 @@ func TestEc2SecurityGroupQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ec2", mc)
 
 			actual, err := api.Query("security-group")
 
@@ -217,7 +239,7 @@ func TestEc2VpcQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEc2API(ctrl)
 
 	mc.EXPECT().
-		DescribeVpcs(gomock.Any(), nil).
+		DescribeVpcs(gomock.Any(), &ec2.DescribeVpcsInput{}).
 		Return(&ec2.DescribeVpcsOutput{
 			Vpcs: []types.Vpc{
 				{
@@ -251,8 +273,8 @@ func TestEc2VpcQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEc2API(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ec2", mc)
 
 			actual, err := api.Query("vpc")
 
@@ -297,3 +319,58 @@ func TestEc2VpcQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestEc2VpcQueryWithTagFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsEc2API(ctrl)
+
+	mc.EXPECT().
+		DescribeVpcs(gomock.Any(), &ec2.DescribeVpcsInput{
+			Filters: []types.Filter{
+				{Name: aws.String("tag:env"), Values: []string{"prod"}},
+			},
+		}).
+		Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []types.Vpc{
+				{
+					VpcId: aws.String("vpc-1234567890abcdef0"),
+					Tags: []types.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+					},
+				},
+				{
+					VpcId: aws.String("vpc-0fedcba0987654321"),
+					Tags: []types.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+						{Key: aws.String("legacy"), Value: aws.String("true")},
+					},
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqEc2API(config, []string{"ap-northeast-1"}, QueryOption{
+		TagFilters: TagFilters{
+			{Key: "env", Value: "prod", HasValue: true},
+			{Key: "legacy", Exclude: true},
+		},
+	})
+	api.clients.put("", "ap-northeast-1", "ec2", mc)
+
+	actual, err := api.Query("vpc")
+	if err != nil {
+		t.Errorf("expected nil, but got %v", err.Error())
+	}
+
+	if len(actual.Results) != 1 {
+		t.Fatalf("expected 1, but got %v", len(actual.Results))
+	}
+	vpc, ok := actual.Results[0].(types.Vpc)
+	if !ok {
+		t.Fatalf("expected types.Vpc, but got %T", actual.Results[0])
+	}
+	if aws.ToString(vpc.VpcId) != "vpc-1234567890abcdef0" {
+		t.Errorf("expected vpc-1234567890abcdef0, but got %v", aws.ToString(vpc.VpcId))
+	}
+}
@@ -3,95 +3,107 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
 )
 
 type awsEcrAPI interface {
 	DescribeRepositories(ctx context.Context, params *ecr.DescribeRepositoriesInput, optFns ...func(*ecr.Options)) (*ecr.DescribeRepositoriesOutput, error)
+	ListTagsForResource(ctx context.Context, params *ecr.ListTagsForResourceInput, optFns ...func(*ecr.Options)) (*ecr.ListTagsForResourceOutput, error)
 }
 
+var ecrService = register(ServiceDefinition{
+	Name:        "ecr",
+	Description: "Amazon Elastic Container Registry",
+	Resources: []ResourceDefinition{
+		newResource("repository", "ECR repositories", []string{"RepositoryName", "RepositoryUri", "ImageTagMutability", "CreatedAt"},
+			func(api *AwsresqEcrAPI) ResourceQueryAPI { return api.queryRepository }).withARN("repository"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqEcrAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqEcrAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsEcrAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqEcrAPI(c aws.Config, region []string) AwsresqEcrAPI {
-	return AwsresqEcrAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsEcrAPI, len(region)),
+func NewAwsresqEcrAPI(c aws.Config, region []string, opt QueryOption) *AwsresqEcrAPI {
+	return &AwsresqEcrAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqEcrAPI) Validate(resource string) bool {
-	validResources := []string{
-		"repository",
-	}
-
-	return slices.Contains(validResources, resource)
+func (api *AwsresqEcrAPI) Validate(resource string) bool {
+	return ecrService.Validate(resource)
 }
 
-func (api AwsresqEcrAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "ecr",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "repository":
-		apiQuery = api.queryRepository
-	default:
-		return nil, fmt.Errorf("resource %s not supported in ecr service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case res := <-ch:
-			resultList.Results = append(resultList.Results, res.Results...)
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqEcrAPI) DefaultColumns(resource string) []string {
+	return ecrService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqEcrAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api AwsresqEcrAPI) queryRepository(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "ecr",
-		Resource: "repository",
-	}
+func (api *AwsresqEcrAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return ecrService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = ecr.NewFromConfig(api.awsCfg, func(o *ecr.Options) {
+func (api *AwsresqEcrAPI) client(region string) awsEcrAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "ecr", func() awsEcrAPI {
+		return ecr.NewFromConfig(api.awsCfg, func(o *ecr.Options) {
 			o.Region = region
 		})
-	}
+	})
+}
 
-	listOutput, err := api.apiClient[region].DescribeRepositories(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("error querying ecr repository in %s: %v", region, err)
-		return
-	}
-	for _, repo := range listOutput.Repositories {
-		resultList.Results = append(resultList.Results, repo)
-	}
+func (api *AwsresqEcrAPI) queryRepository(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEcrAPI, *ecr.DescribeRepositoriesOutput, types.Repository, types.Repository]{
+		service:  "ecr",
+		resource: "repository",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEcrAPI, _ string, token *string) (*ecr.DescribeRepositoriesOutput, error) {
+			return client.DescribeRepositories(ctx, &ecr.DescribeRepositoriesInput{NextToken: token})
+		},
+		lookup: func(ctx context.Context, client awsEcrAPI, target ResourceARN) (string, *ecr.DescribeRepositoriesOutput, error) {
+			output, err := client.DescribeRepositories(ctx, &ecr.DescribeRepositoriesInput{
+				RepositoryNames: []string{target.ID},
+			})
+			return "", output, err
+		},
+		items: func(output *ecr.DescribeRepositoriesOutput) ([]types.Repository, *string) {
+			return output.Repositories, output.NextToken
+		},
+		tags: func(ctx context.Context, client awsEcrAPI, repo types.Repository) (map[string]string, error) {
+			output, err := client.ListTagsForResource(ctx, &ecr.ListTagsForResourceInput{
+				ResourceArn: repo.RepositoryArn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return ecrTagMap(output.Tags), nil
+		},
+		envelope: func(repo types.Repository) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(repo.RepositoryArn),
+				ID:        aws.ToString(repo.RepositoryName),
+				Name:      aws.ToString(repo.RepositoryName),
+				CreatedAt: repo.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func ecrTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
@@ -51,13 +51,25 @@ func TestEcrRepositoryQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEcrAPI(ctrl)
 
 	mc.EXPECT().
-		DescribeRepositories(gomock.Any(), nil).
+		DescribeRepositories(gomock.Any(), &ecr.DescribeRepositoriesInput{}).
 		Return(&ecr.DescribeRepositoriesOutput{
 			Repositories: []types.Repository{
 				{
 					RepositoryName: aws.String("test"),
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil)
+	mc.EXPECT().
+		DescribeRepositories(gomock.Any(), &ecr.DescribeRepositoriesInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&ecr.DescribeRepositoriesOutput{
+			Repositories: []types.Repository{
+				{
+					RepositoryName: aws.String("sample"),
+				},
+			},
 		}, nil)
 
 	cases := []struct {
@@ -72,6 +84,9 @@ func TestEcrRepositoryQuery(t *testing.T) {
 				{
 					RepositoryName: aws.String("test"),
 				},
+				{
+					RepositoryName: aws.String("sample"),
+				},
 			},
 			wantErr: false,
 		},
@@ -80,8 +95,8 @@ func TestEcrRepositoryQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.Background())
-			api := NewAwsresqEcrAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEcrAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecr", mc)
 
 			actual, err := api.Query("repository")
 
@@ -4,17 +4,13 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
 )
 
-type ResourceQueryAPI func(ctx context.Context, ch chan ResultList, region string)
-
 type awsEcsAPI interface {
 	ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error)
 	DescribeClusters(ctx context.Context, params *ecs.DescribeClustersInput, optFns ...func(*ecs.Options)) (*ecs.DescribeClustersOutput, error)
@@ -26,244 +22,299 @@ type awsEcsAPI interface {
 	DescribeTaskDefinition(ctx context.Context, params *ecs.DescribeTaskDefinitionInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error)
 }
 
+var ecsService = register(ServiceDefinition{
+	Name:        "ecs",
+	Description: "Amazon Elastic Container Service",
+	Resources: []ResourceDefinition{
+		newResource("cluster", "ECS clusters", []string{"ClusterName", "Status", "ActiveServicesCount", "RunningTasksCount"},
+			func(api *AwsresqEcsAPI) ResourceQueryAPI { return api.queryCluster }).withARN("cluster"),
+		newResource("service", "ECS services of every cluster", []string{"ServiceName", "Status", "LaunchType", "DesiredCount", "RunningCount"},
+			func(api *AwsresqEcsAPI) ResourceQueryAPI { return api.queryService }).
+			withARN("service").
+			withFilters(singleValueFilters("launch-type", "scheduling-strategy")...),
+		newResource("task", "ECS tasks of every cluster", []string{"TaskArn", "LastStatus", "LaunchType", "TaskDefinitionArn"},
+			func(api *AwsresqEcsAPI) ResourceQueryAPI { return api.queryTask }).
+			withARN("task").
+			withFilters(singleValueFilters("desired-status", "launch-type", "family", "service-name")...),
+		newResource("task-definition", "ECS task definitions", []string{"Family", "Revision", "Status", "Cpu", "Memory"},
+			func(api *AwsresqEcsAPI) ResourceQueryAPI { return api.queryTaskDefinition }).
+			withARN("task-definition").
+			withFilters(singleValueFilters("family-prefix", "status")...),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqEcsAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqEcsAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsEcsAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqEcsAPI(c aws.Config, region []string) AwsresqEcsAPI {
-	return AwsresqEcsAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsEcsAPI, len(region)),
+func NewAwsresqEcsAPI(c aws.Config, region []string, opt QueryOption) *AwsresqEcsAPI {
+	return &AwsresqEcsAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqEcsAPI) Validate(resource string) bool {
-	validResources := []string{
-		"cluster",
-		"service",
-		"task",
-		"task-definition",
-	}
-
-	return slices.Contains(validResources, resource)
+func (api *AwsresqEcsAPI) Validate(resource string) bool {
+	return ecsService.Validate(resource)
 }
 
-func (api AwsresqEcsAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "ecs",
-		Resource: resource,
-	}
-	var err error = nil
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "cluster":
-		apiQuery = api.queryCluster
-	case "service":
-		apiQuery = api.queryService
-	case "task":
-		apiQuery = api.queryTask
-	case "task-definition":
-		apiQuery = api.queryTaskDefinition
-	default:
-		return nil, fmt.Errorf("resource '%s' not supported in ecs service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			if result.Results != nil {
-				resultList.Results = append(resultList.Results, result.Results...)
-			}
-		case <-ctx.Done():
-			return resultList, ctx.Err()
-		}
-	}
-
-	return resultList, err
+func (api *AwsresqEcsAPI) DefaultColumns(resource string) []string {
+	return ecsService.DefaultColumns(resource)
 }
 
-func (api *AwsresqEcsAPI) queryCluster(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "ecs",
-		Resource: "cluster",
-	}
-
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = ecs.NewFromConfig(api.awsCfg, func(o *ecs.Options) {
-			o.Region = r
-		})
-	}
-
-	listOutput, err := api.apiClient[r].ListClusters(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("error listing clusters in region %s: %s", r, err)
-		return
-	}
-	for _, arn := range listOutput.ClusterArns {
-		input := &ecs.DescribeClustersInput{
-			Clusters: []string{arn},
-			Include: []types.ClusterField{
-				types.ClusterFieldTags,
-				types.ClusterFieldStatistics,
-				types.ClusterFieldSettings,
-				types.ClusterFieldConfigurations,
-				types.ClusterFieldAttachments,
-			},
-		}
-		output, err := api.apiClient[r].DescribeClusters(ctx, input)
-		if err != nil {
-			log.Error().Msgf("error describing cluster %s in region %s: %s", arn, r, err)
-			return
-		}
-
-		for _, cluster := range output.Clusters {
-			resultList.Results = append(resultList.Results, cluster)
-		}
-	}
-
-	ch <- resultList
+func (api *AwsresqEcsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqEcsAPI) queryTaskDefinition(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "ecs",
-		Resource: "task-definition",
-	}
-
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = ecs.NewFromConfig(api.awsCfg, func(o *ecs.Options) {
-			o.Region = r
-		})
-	}
-
-	listOutput, err := api.apiClient[r].ListTaskDefinitions(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("Failed to list task definitions in %s", r)
-		return
-	}
-	for _, arn := range listOutput.TaskDefinitionArns {
-		input := &ecs.DescribeTaskDefinitionInput{
-			TaskDefinition: aws.String(arn),
-			Include: []types.TaskDefinitionField{
-				types.TaskDefinitionFieldTags,
-			},
-		}
-		output, err := api.apiClient[r].DescribeTaskDefinition(ctx, input)
-		if err != nil {
-			log.Error().Err(err).Msgf("Failed to describe task definition in %s", r)
-			return
-		}
-
-		resultList.Results = append(resultList.Results, output.TaskDefinition)
-	}
-
-	ch <- resultList
+func (api *AwsresqEcsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return ecsService.query(ctx, api, resource, api.region, api.opt)
 }
 
-func (api *AwsresqEcsAPI) queryService(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "ecs",
-		Resource: "service",
-	}
-
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = ecs.NewFromConfig(api.awsCfg, func(o *ecs.Options) {
-			o.Region = r
+func (api *AwsresqEcsAPI) client(region string) awsEcsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "ecs", func() awsEcsAPI {
+		return ecs.NewFromConfig(api.awsCfg, func(o *ecs.Options) {
+			o.Region = region
 		})
-	}
+	})
+}
 
-	clusterOutput, err := api.apiClient[r].ListClusters(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("error listing clusters in region %s: %s", r, err)
-		return
-	}
+func (api *AwsresqEcsAPI) queryCluster(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEcsAPI, *ecs.ListClustersOutput, string, types.Cluster]{
+		service:  "ecs",
+		resource: "cluster",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEcsAPI, _ string, token *string) (*ecs.ListClustersOutput, error) {
+			return client.ListClusters(ctx, &ecs.ListClustersInput{NextToken: token})
+		},
+		lookup: func(_ context.Context, _ awsEcsAPI, target ResourceARN) (string, *ecs.ListClustersOutput, error) {
+			return "", &ecs.ListClustersOutput{ClusterArns: []string{target.String()}}, nil
+		},
+		items: func(output *ecs.ListClustersOutput) ([]string, *string) {
+			return output.ClusterArns, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEcsAPI, _ string, arns []string) ([]types.Cluster, error) {
+			output, err := client.DescribeClusters(ctx, &ecs.DescribeClustersInput{
+				Clusters: arns,
+				Include: []types.ClusterField{
+					types.ClusterFieldTags,
+					types.ClusterFieldStatistics,
+					types.ClusterFieldSettings,
+					types.ClusterFieldConfigurations,
+					types.ClusterFieldAttachments,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.Clusters, nil
+		},
+		batchSize: 100,
+		tags: func(_ context.Context, _ awsEcsAPI, cluster types.Cluster) (map[string]string, error) {
+			return ecsTagMap(cluster.Tags), nil
+		},
+		envelope: func(cluster types.Cluster) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(cluster.ClusterArn),
+				ID:   aws.ToString(cluster.ClusterArn),
+				Name: aws.ToString(cluster.ClusterName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	for _, clusterArn := range clusterOutput.ClusterArns {
-		input := &ecs.ListServicesInput{
-			Cluster: aws.String(clusterArn),
-		}
-		listService, err := api.apiClient[r].ListServices(ctx, input)
-		if err != nil {
-			log.Error().Msgf("error listing services in region %s: %s", r, err)
-			return
-		}
+func (api *AwsresqEcsAPI) queryTaskDefinition(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEcsAPI, *ecs.ListTaskDefinitionsOutput, string, *ecs.DescribeTaskDefinitionOutput]{
+		service:  "ecs",
+		resource: "task-definition",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEcsAPI, _ string, token *string) (*ecs.ListTaskDefinitionsOutput, error) {
+			return client.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{
+				FamilyPrefix: optionalString(api.opt.Filters.value("family-prefix")),
+				Status:       types.TaskDefinitionStatus(api.opt.Filters.value("status")),
+				NextToken:    token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEcsAPI, target ResourceARN) (string, *ecs.ListTaskDefinitionsOutput, error) {
+			return "", &ecs.ListTaskDefinitionsOutput{TaskDefinitionArns: []string{target.String()}}, nil
+		},
+		items: func(output *ecs.ListTaskDefinitionsOutput) ([]string, *string) {
+			return output.TaskDefinitionArns, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEcsAPI, _ string, arns []string) ([]*ecs.DescribeTaskDefinitionOutput, error) {
+			output, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+				TaskDefinition: aws.String(arns[0]),
+				Include: []types.TaskDefinitionField{
+					types.TaskDefinitionFieldTags,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []*ecs.DescribeTaskDefinitionOutput{output}, nil
+		},
+		batchSize: 1,
+		// the tags are returned apart from the task definition
+		tags: func(_ context.Context, _ awsEcsAPI, output *ecs.DescribeTaskDefinitionOutput) (map[string]string, error) {
+			return ecsTagMap(output.Tags), nil
+		},
+		raw: func(output *ecs.DescribeTaskDefinitionOutput) interface{} {
+			return output.TaskDefinition
+		},
+		envelope: func(output *ecs.DescribeTaskDefinitionOutput) Envelope {
+			taskDefinition := output.TaskDefinition
+			return Envelope{
+				ARN:       aws.ToString(taskDefinition.TaskDefinitionArn),
+				ID:        aws.ToString(taskDefinition.TaskDefinitionArn),
+				Name:      fmt.Sprintf("%s:%d", aws.ToString(taskDefinition.Family), taskDefinition.Revision),
+				CreatedAt: taskDefinition.RegisteredAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-		for _, arn := range listService.ServiceArns {
-			input := &ecs.DescribeServicesInput{
-				Cluster:  aws.String(clusterArn),
-				Services: []string{arn},
+func (api *AwsresqEcsAPI) queryService(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEcsAPI, *ecs.ListServicesOutput, string, types.Service]{
+		service:  "ecs",
+		resource: "service",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  listClusterArns,
+		list: func(ctx context.Context, client awsEcsAPI, cluster string, token *string) (*ecs.ListServicesOutput, error) {
+			return client.ListServices(ctx, &ecs.ListServicesInput{
+				Cluster:            aws.String(cluster),
+				LaunchType:         types.LaunchType(api.opt.Filters.value("launch-type")),
+				SchedulingStrategy: types.SchedulingStrategy(api.opt.Filters.value("scheduling-strategy")),
+				NextToken:          token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEcsAPI, target ResourceARN) (string, *ecs.ListServicesOutput, error) {
+			cluster, err := ecsClusterOfARN(target)
+			if err != nil {
+				return "", nil, err
 			}
-			output, err := api.apiClient[r].DescribeServices(ctx, input)
+			return cluster, &ecs.ListServicesOutput{ServiceArns: []string{target.String()}}, nil
+		},
+		items: func(output *ecs.ListServicesOutput) ([]string, *string) {
+			return output.ServiceArns, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEcsAPI, cluster string, arns []string) ([]types.Service, error) {
+			output, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+				Cluster:  aws.String(cluster),
+				Services: arns,
+				Include: []types.ServiceField{
+					types.ServiceFieldTags,
+				},
+			})
 			if err != nil {
-				log.Error().Msgf("error describing service %s in region %s: %s", arn, r, err)
-				return
+				return nil, err
 			}
-
-			for _, service := range output.Services {
-				resultList.Results = append(resultList.Results, service)
+			return output.Services, nil
+		},
+		batchSize: 10,
+		tags: func(_ context.Context, _ awsEcsAPI, service types.Service) (map[string]string, error) {
+			return ecsTagMap(service.Tags), nil
+		},
+		envelope: func(service types.Service) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(service.ServiceArn),
+				ID:        aws.ToString(service.ServiceArn),
+				Name:      aws.ToString(service.ServiceName),
+				CreatedAt: service.CreatedAt,
 			}
-		}
-	}
-
-	ch <- resultList
+		},
+	}.run(ctx, ch, region)
 }
 
-func (api *AwsresqEcsAPI) queryTask(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "ecs",
-		Resource: "task",
-	}
-
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = ecs.NewFromConfig(api.awsCfg, func(o *ecs.Options) {
-			o.Region = r
-		})
-	}
+func (api *AwsresqEcsAPI) queryTask(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEcsAPI, *ecs.ListTasksOutput, string, types.Task]{
+		service:  "ecs",
+		resource: "task",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  listClusterArns,
+		list: func(ctx context.Context, client awsEcsAPI, cluster string, token *string) (*ecs.ListTasksOutput, error) {
+			return client.ListTasks(ctx, &ecs.ListTasksInput{
+				Cluster:       aws.String(cluster),
+				DesiredStatus: types.DesiredStatus(api.opt.Filters.value("desired-status")),
+				LaunchType:    types.LaunchType(api.opt.Filters.value("launch-type")),
+				Family:        optionalString(api.opt.Filters.value("family")),
+				ServiceName:   optionalString(api.opt.Filters.value("service-name")),
+				NextToken:     token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEcsAPI, target ResourceARN) (string, *ecs.ListTasksOutput, error) {
+			cluster, err := ecsClusterOfARN(target)
+			if err != nil {
+				return "", nil, err
+			}
+			return cluster, &ecs.ListTasksOutput{TaskArns: []string{target.String()}}, nil
+		},
+		items: func(output *ecs.ListTasksOutput) ([]string, *string) {
+			return output.TaskArns, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEcsAPI, cluster string, arns []string) ([]types.Task, error) {
+			output, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+				Cluster: aws.String(cluster),
+				Tasks:   arns,
+				Include: []types.TaskField{
+					types.TaskFieldTags,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.Tasks, nil
+		},
+		batchSize: 100,
+		tags: func(_ context.Context, _ awsEcsAPI, task types.Task) (map[string]string, error) {
+			return ecsTagMap(task.Tags), nil
+		},
+		envelope: func(task types.Task) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(task.TaskArn),
+				ID:        aws.ToString(task.TaskArn),
+				CreatedAt: task.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	clusterOutput, err := api.apiClient[r].ListClusters(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("error listing clusters in region %s: %s", r, err)
-		return
-	}
+// listClusterArns returns the ARNs of all clusters in the region, following every page.
+func listClusterArns(ctx context.Context, client awsEcsAPI) ([]string, error) {
+	var clusterArns []string
 
-	for _, clusterArn := range clusterOutput.ClusterArns {
-		input := &ecs.ListTasksInput{
-			Cluster: aws.String(clusterArn),
-		}
-		listTask, err := api.apiClient[r].ListTasks(ctx, input)
+	paginator := ecs.NewListClustersPaginator(client, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
 		if err != nil {
-			log.Error().Msgf("error listing tasks in region %s: %s", r, err)
-			return
+			return nil, err
 		}
+		clusterArns = append(clusterArns, output.ClusterArns...)
+	}
 
-		for _, arn := range listTask.TaskArns {
-			input := &ecs.DescribeTasksInput{
-				Cluster: aws.String(clusterArn),
-				Tasks:   []string{arn},
-			}
-			output, err := api.apiClient[r].DescribeTasks(ctx, input)
-			if err != nil {
-				log.Error().Msgf("error describing task %s in region %s: %s", arn, r, err)
-				return
-			}
+	return clusterArns, nil
+}
 
-			for _, task := range output.Tasks {
-				resultList.Results = append(resultList.Results, task)
-			}
-		}
+// ecsClusterOfARN returns the cluster name in the ARN of a service or a task, which is needed to describe them.
+// ARNs in the old format do not include the cluster.
+func ecsClusterOfARN(target ResourceARN) (string, error) {
+	cluster, _, ok := strings.Cut(target.ID, "/")
+	if !ok {
+		return "", fmt.Errorf("ARN does not include the cluster of the %s: '%s'", target.Type, target.String())
 	}
+	return cluster, nil
+}
 
-	ch <- resultList
+func ecsTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	"github.com/thaim/awsresq/mock"
 )
@@ -69,11 +71,22 @@ func TestEcsClusterQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEcsAPI(ctrl)
 
 	mc.EXPECT().
-		ListClusters(gomock.Any(), nil).
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
 		Return(&ecs.ListClustersOutput{
 			ClusterArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/test-cluster",
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&ecs.ListClustersOutput{
+			ClusterArns: []string{
+				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/sample-cluster",
+			},
 		}, nil).
 		AnyTimes()
 	mc.EXPECT().
@@ -98,6 +111,28 @@ func TestEcsClusterQuery(t *testing.T) {
 			},
 		}, nil).
 		AnyTimes()
+	mc.EXPECT().
+		DescribeClusters(gomock.Any(), &ecs.DescribeClustersInput{
+			Clusters: []string{
+				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/sample-cluster",
+			},
+			Include: []types.ClusterField{
+				types.ClusterFieldTags,
+				types.ClusterFieldStatistics,
+				types.ClusterFieldSettings,
+				types.ClusterFieldConfigurations,
+				types.ClusterFieldAttachments,
+			},
+		}).
+		Return(&ecs.DescribeClustersOutput{
+			Clusters: []types.Cluster{
+				{
+					ClusterArn:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/sample-cluster"),
+					ClusterName: aws.String("sample-cluster"),
+				},
+			},
+		}, nil).
+		AnyTimes()
 
 	cases := []struct {
 		name      string
@@ -112,6 +147,10 @@ func TestEcsClusterQuery(t *testing.T) {
 					ClusterArn:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/test-cluster"),
 					ClusterName: aws.String("test-cluster"),
 				},
+				{
+					ClusterArn:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/sample-cluster"),
+					ClusterName: aws.String("sample-cluster"),
+				},
 			},
 			wantErr: false,
 		},
@@ -120,8 +159,8 @@ func TestEcsClusterQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecs", mc)
 
 			actual, err := api.Query("cluster")
 
@@ -164,12 +203,110 @@ func TestEcsClusterQuery(t *testing.T) {
 	}
 }
 
+func TestEcsClusterQueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsEcsAPI(ctrl)
+	mcFailed := mock_service.NewMockawsEcsAPI(ctrl)
+
+	mc.EXPECT().
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
+		Return(&ecs.ListClustersOutput{
+			ClusterArns: []string{
+				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/test-cluster",
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeClusters(gomock.Any(), gomock.Any()).
+		Return(&ecs.DescribeClustersOutput{
+			Clusters: []types.Cluster{
+				{
+					ClusterArn:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/test-cluster"),
+					ClusterName: aws.String("test-cluster"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcFailed.EXPECT().
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
+		Return(nil, &smithy.OperationError{
+			ServiceID:     "ECS",
+			OperationName: "ListClusters",
+			Err: &smithy.GenericAPIError{
+				Code:    "AccessDeniedException",
+				Message: "User is not authorized to perform: ecs:ListClusters",
+			},
+		}).
+		AnyTimes()
+
+	cases := []struct {
+		name           string
+		region         []string
+		expectedCount  int
+		expectedErrors []QueryError
+		expectErr      error
+	}{
+		{
+			name:          "query cluster resource with partial failure",
+			region:        []string{"ap-northeast-1", "us-east-1"},
+			expectedCount: 1,
+			expectedErrors: []QueryError{
+				{
+					Region:    "us-east-1",
+					Operation: "ListClusters",
+					Code:      "AccessDeniedException",
+					Message:   "User is not authorized to perform: ecs:ListClusters",
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			name:          "query cluster resource with total failure",
+			region:        []string{"us-east-1"},
+			expectedCount: 0,
+			expectedErrors: []QueryError{
+				{
+					Region:    "us-east-1",
+					Operation: "ListClusters",
+					Code:      "AccessDeniedException",
+					Message:   "User is not authorized to perform: ecs:ListClusters",
+				},
+			},
+			expectErr: ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqEcsAPI(config, tt.region, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecs", mc)
+			api.clients.put("", "us-east-1", "ecs", mcFailed)
+
+			actual, err := api.Query("cluster")
+
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected %v, but got %v", tt.expectErr, err)
+			}
+			if actual == nil {
+				t.Fatalf("expected result list, but got nil")
+			}
+			if len(actual.Results) != tt.expectedCount {
+				t.Errorf("expected %v, but got %v", tt.expectedCount, len(actual.Results))
+			}
+			if !reflect.DeepEqual(actual.Errors, tt.expectedErrors) {
+				t.Errorf("expected %+v, but got %+v", tt.expectedErrors, actual.Errors)
+			}
+		})
+	}
+}
+
 func TestEcsTaskQuery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mc := mock_service.NewMockawsEcsAPI(ctrl)
 
 	mc.EXPECT().
-		ListClusters(gomock.Any(), nil).
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
 		Return(&ecs.ListClustersOutput{
 			ClusterArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/testcluster01",
@@ -185,6 +322,17 @@ func TestEcsTaskQuery(t *testing.T) {
 		Return(&ecs.ListTasksOutput{
 			TaskArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:task/testcluster01/74de0355a10a4f979ac495c14EXAMPLE",
+			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTasks(gomock.Any(), &ecs.ListTasksInput{
+			Cluster:   aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/testcluster01"),
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&ecs.ListTasksOutput{
+			TaskArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:task/testcluster01/d789e94343414c25b9f6bd59eEXAMPLE",
 			},
 		}, nil).
@@ -204,6 +352,9 @@ func TestEcsTaskQuery(t *testing.T) {
 			Tasks: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:task/testcluster01/74de0355a10a4f979ac495c14EXAMPLE",
 			},
+			Include: []types.TaskField{
+				types.TaskFieldTags,
+			},
 		}).
 		Return(&ecs.DescribeTasksOutput{
 			Tasks: []types.Task{
@@ -219,6 +370,9 @@ func TestEcsTaskQuery(t *testing.T) {
 			Tasks: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:task/testcluster01/d789e94343414c25b9f6bd59eEXAMPLE",
 			},
+			Include: []types.TaskField{
+				types.TaskFieldTags,
+			},
 		}).
 		Return(&ecs.DescribeTasksOutput{
 			Tasks: []types.Task{
@@ -253,8 +407,8 @@ func TestEcsTaskQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecs", mc)
 
 			actual, err := api.Query(tt.resource)
 
@@ -298,7 +452,7 @@ func TestEcsTaskDefinitionQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEcsAPI(ctrl)
 
 	mc.EXPECT().
-		ListTaskDefinitions(gomock.Any(), nil).
+		ListTaskDefinitions(gomock.Any(), &ecs.ListTaskDefinitionsInput{}).
 		Return(&ecs.ListTaskDefinitionsOutput{
 			TaskDefinitionArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:task-definition/testapp:1",
@@ -378,8 +532,8 @@ func TestEcsTaskDefinitionQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecs", mc)
 
 			actual, err := api.Query(tt.resource)
 
@@ -423,7 +577,7 @@ func TestEcsServiceQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEcsAPI(ctrl)
 
 	mc.EXPECT().
-		ListClusters(gomock.Any(), nil).
+		ListClusters(gomock.Any(), &ecs.ListClustersInput{}).
 		Return(&ecs.ListClustersOutput{
 			ClusterArns: []string{
 				"arn:aws:ecs:ap-northeast-1:012345678901:cluster/testcluster01",
@@ -457,6 +611,9 @@ func TestEcsServiceQuery(t *testing.T) {
 		DescribeServices(gomock.Any(), &ecs.DescribeServicesInput{
 			Cluster:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/testcluster01"),
 			Services: []string{"arn:aws:ecs:ap-northeast-1:012345678901:service/testcluster01/testservice01"},
+			Include: []types.ServiceField{
+				types.ServiceFieldTags,
+			},
 		}).
 		Return(&ecs.DescribeServicesOutput{
 			Services: []types.Service{
@@ -472,6 +629,9 @@ func TestEcsServiceQuery(t *testing.T) {
 		DescribeServices(gomock.Any(), &ecs.DescribeServicesInput{
 			Cluster:  aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/testcluster02"),
 			Services: []string{"arn:aws:ecs:ap-northeast-1:012345678901:service/testcluster02/testservice02"},
+			Include: []types.ServiceField{
+				types.ServiceFieldTags,
+			},
 		}).
 		Return(&ecs.DescribeServicesOutput{
 			Services: []types.Service{
@@ -510,8 +670,8 @@ func TestEcsServiceQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEcsAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "ecs", mc)
 
 			actual, err := api.Query("service")
 
@@ -3,97 +3,101 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/efs"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/efs/types"
 )
 
 type awsEfsAPI interface {
 	DescribeFileSystems(ctx context.Context, params *efs.DescribeFileSystemsInput, optFns ...func(*efs.Options)) (*efs.DescribeFileSystemsOutput, error)
 }
 
+var efsService = register(ServiceDefinition{
+	Name:         "efs",
+	Description:  "Amazon Elastic File System",
+	ARNNamespace: "elasticfilesystem",
+	Resources: []ResourceDefinition{
+		newResource("file-system", "EFS file systems", []string{"FileSystemId", "Name", "LifeCycleState", "SizeInBytes.Value"},
+			func(api *AwsresqEfsAPI) ResourceQueryAPI { return api.queryFileSystem }).withARN("file-system"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqEfsAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqEfsAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsEfsAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqEfsAPI(awsCfg aws.Config, region []string) *AwsresqEfsAPI {
+func NewAwsresqEfsAPI(awsCfg aws.Config, region []string, opt QueryOption) *AwsresqEfsAPI {
 	return &AwsresqEfsAPI{
-		awsCfg:    awsCfg,
-		region:    region,
-		apiClient: make(map[string]awsEfsAPI, len(region)),
+		awsCfg:  awsCfg,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (a *AwsresqEfsAPI) Validate(resource string) bool {
-	validResources := []string{
-		"file-system",
-	}
-
-	return slices.Contains(validResources, resource)
+func (api *AwsresqEfsAPI) Validate(resource string) bool {
+	return efsService.Validate(resource)
 }
 
-func (api AwsresqEfsAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "efs",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "file-system":
-		apiQuery = api.queryFileSystem
-	default:
-		return nil, fmt.Errorf("resource %s not supported in efs service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqEfsAPI) DefaultColumns(resource string) []string {
+	return efsService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqEfsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqEfsAPI) queryFileSystem(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "efs",
-		Resource: "file-system",
-	}
+func (api *AwsresqEfsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return efsService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = efs.NewFromConfig(api.awsCfg, func(o *efs.Options) {
-			o.Region = r
+func (api *AwsresqEfsAPI) client(region string) awsEfsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "efs", func() awsEfsAPI {
+		return efs.NewFromConfig(api.awsCfg, func(o *efs.Options) {
+			o.Region = region
 		})
-	}
+	})
+}
 
-	listOutput, err := api.apiClient[r].DescribeFileSystems(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("Failed to describe file systems in %s", r)
-		return
-	}
-	if len(listOutput.FileSystems) > 0 {
-		for _, fs := range listOutput.FileSystems {
-			resultList.Results = append(resultList.Results, fs)
-		}
-	}
+func (api *AwsresqEfsAPI) queryFileSystem(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEfsAPI, *efs.DescribeFileSystemsOutput, types.FileSystemDescription, types.FileSystemDescription]{
+		service:  "efs",
+		resource: "file-system",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEfsAPI, _ string, token *string) (*efs.DescribeFileSystemsOutput, error) {
+			return client.DescribeFileSystems(ctx, &efs.DescribeFileSystemsInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsEfsAPI, target ResourceARN) (string, *efs.DescribeFileSystemsOutput, error) {
+			output, err := client.DescribeFileSystems(ctx, &efs.DescribeFileSystemsInput{
+				FileSystemId: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *efs.DescribeFileSystemsOutput) ([]types.FileSystemDescription, *string) {
+			return output.FileSystems, output.NextMarker
+		},
+		tags: func(_ context.Context, _ awsEfsAPI, fs types.FileSystemDescription) (map[string]string, error) {
+			return efsTagMap(fs.Tags), nil
+		},
+		envelope: func(fs types.FileSystemDescription) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(fs.FileSystemArn),
+				ID:        aws.ToString(fs.FileSystemId),
+				Name:      aws.ToString(fs.Name),
+				CreatedAt: fs.CreationTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func efsTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
@@ -51,13 +51,26 @@ func TestEfsFileSystemQuery(t *testing.T) {
 	mc := mock_service.NewMockawsEfsAPI(ctrl)
 
 	mc.EXPECT().
-		DescribeFileSystems(gomock.Any(), nil).
+		DescribeFileSystems(gomock.Any(), &efs.DescribeFileSystemsInput{}).
 		Return(&efs.DescribeFileSystemsOutput{
 			FileSystems: []types.FileSystemDescription{
 				{
 					FileSystemId: aws.String("fs-0123456789abcdef0"),
 				},
 			},
+			NextMarker: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeFileSystems(gomock.Any(), &efs.DescribeFileSystemsInput{
+			Marker: aws.String("next-token"),
+		}).
+		Return(&efs.DescribeFileSystemsOutput{
+			FileSystems: []types.FileSystemDescription{
+				{
+					FileSystemId: aws.String("fs-0123456789abcdef1"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -73,6 +86,9 @@ func TestEfsFileSystemQuery(t *testing.T) {
 				{
 					FileSystemId: aws.String("fs-0123456789abcdef0"),
 				},
+				{
+					FileSystemId: aws.String("fs-0123456789abcdef1"),
+				},
 			},
 			wantErr: false,
 		},
@@ -81,8 +97,8 @@ func TestEfsFileSystemQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqEfsAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqEfsAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "efs", mc)
 
 			actual, err := api.Query("file-system")
 
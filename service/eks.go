@@ -0,0 +1,289 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+type awsEksAPI interface {
+	ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error)
+	DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error)
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error)
+	ListFargateProfiles(ctx context.Context, params *eks.ListFargateProfilesInput, optFns ...func(*eks.Options)) (*eks.ListFargateProfilesOutput, error)
+	DescribeFargateProfile(ctx context.Context, params *eks.DescribeFargateProfileInput, optFns ...func(*eks.Options)) (*eks.DescribeFargateProfileOutput, error)
+	ListAddons(ctx context.Context, params *eks.ListAddonsInput, optFns ...func(*eks.Options)) (*eks.ListAddonsOutput, error)
+	DescribeAddon(ctx context.Context, params *eks.DescribeAddonInput, optFns ...func(*eks.Options)) (*eks.DescribeAddonOutput, error)
+}
+
+var eksService = register(ServiceDefinition{
+	Name:        "eks",
+	Description: "Amazon Elastic Kubernetes Service",
+	Resources: []ResourceDefinition{
+		newResource("cluster", "EKS clusters with their version, endpoint access and logging", []string{"Name", "Version", "Status", "ResourcesVpcConfig.EndpointPublicAccess", "ResourcesVpcConfig.EndpointPrivateAccess"},
+			func(api *AwsresqEksAPI) ResourceQueryAPI { return api.queryCluster }).withARN("cluster"),
+		newResource("nodegroup", "managed node groups of every cluster", []string{"ClusterName", "NodegroupName", "Status", "CapacityType", "ScalingConfig.DesiredSize"},
+			func(api *AwsresqEksAPI) ResourceQueryAPI { return api.queryNodegroup }).withARN("nodegroup"),
+		newResource("fargate-profile", "Fargate profiles of every cluster", []string{"ClusterName", "FargateProfileName", "Status", "Selectors.Namespace"},
+			func(api *AwsresqEksAPI) ResourceQueryAPI { return api.queryFargateProfile }).withARN("fargateprofile"),
+		newResource("addon", "add-ons installed in every cluster", []string{"ClusterName", "AddonName", "AddonVersion", "Status"},
+			func(api *AwsresqEksAPI) ResourceQueryAPI { return api.queryAddon }).withARN("addon"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqEksAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqEksAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqEksAPI(c aws.Config, region []string, opt QueryOption) *AwsresqEksAPI {
+	return &AwsresqEksAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqEksAPI) Validate(resource string) bool {
+	return eksService.Validate(resource)
+}
+
+func (api *AwsresqEksAPI) DefaultColumns(resource string) []string {
+	return eksService.DefaultColumns(resource)
+}
+
+func (api *AwsresqEksAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqEksAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return eksService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqEksAPI) client(region string) awsEksAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "eks", func() awsEksAPI {
+		return eks.NewFromConfig(api.awsCfg, func(o *eks.Options) {
+			o.Region = region
+		})
+	})
+}
+
+func (api *AwsresqEksAPI) queryCluster(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEksAPI, *eks.ListClustersOutput, string, *types.Cluster]{
+		service:  "eks",
+		resource: "cluster",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsEksAPI, _ string, token *string) (*eks.ListClustersOutput, error) {
+			return client.ListClusters(ctx, &eks.ListClustersInput{NextToken: token})
+		},
+		lookup: func(_ context.Context, _ awsEksAPI, target ResourceARN) (string, *eks.ListClustersOutput, error) {
+			return "", &eks.ListClustersOutput{Clusters: []string{target.ID}}, nil
+		},
+		items: func(output *eks.ListClustersOutput) ([]string, *string) {
+			return output.Clusters, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEksAPI, _ string, names []string) ([]*types.Cluster, error) {
+			output, err := client.DescribeCluster(ctx, &eks.DescribeClusterInput{
+				Name: aws.String(names[0]),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []*types.Cluster{output.Cluster}, nil
+		},
+		batchSize: 1,
+		tags: func(_ context.Context, _ awsEksAPI, cluster *types.Cluster) (map[string]string, error) {
+			return cluster.Tags, nil
+		},
+		envelope: func(cluster *types.Cluster) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(cluster.Arn),
+				ID:        aws.ToString(cluster.Name),
+				Name:      aws.ToString(cluster.Name),
+				CreatedAt: cluster.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqEksAPI) queryNodegroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEksAPI, *eks.ListNodegroupsOutput, string, *types.Nodegroup]{
+		service:  "eks",
+		resource: "nodegroup",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  listEksClusterNames,
+		list: func(ctx context.Context, client awsEksAPI, cluster string, token *string) (*eks.ListNodegroupsOutput, error) {
+			return client.ListNodegroups(ctx, &eks.ListNodegroupsInput{
+				ClusterName: aws.String(cluster),
+				NextToken:   token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEksAPI, target ResourceARN) (string, *eks.ListNodegroupsOutput, error) {
+			cluster, name, err := eksNamesOfARN(target)
+			if err != nil {
+				return "", nil, err
+			}
+			return cluster, &eks.ListNodegroupsOutput{Nodegroups: []string{name}}, nil
+		},
+		items: func(output *eks.ListNodegroupsOutput) ([]string, *string) {
+			return output.Nodegroups, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEksAPI, cluster string, names []string) ([]*types.Nodegroup, error) {
+			output, err := client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String(cluster),
+				NodegroupName: aws.String(names[0]),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []*types.Nodegroup{output.Nodegroup}, nil
+		},
+		batchSize: 1,
+		tags: func(_ context.Context, _ awsEksAPI, nodegroup *types.Nodegroup) (map[string]string, error) {
+			return nodegroup.Tags, nil
+		},
+		envelope: func(nodegroup *types.Nodegroup) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(nodegroup.NodegroupArn),
+				ID:        aws.ToString(nodegroup.NodegroupArn),
+				Name:      aws.ToString(nodegroup.NodegroupName),
+				CreatedAt: nodegroup.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqEksAPI) queryFargateProfile(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEksAPI, *eks.ListFargateProfilesOutput, string, *types.FargateProfile]{
+		service:  "eks",
+		resource: "fargate-profile",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  listEksClusterNames,
+		list: func(ctx context.Context, client awsEksAPI, cluster string, token *string) (*eks.ListFargateProfilesOutput, error) {
+			return client.ListFargateProfiles(ctx, &eks.ListFargateProfilesInput{
+				ClusterName: aws.String(cluster),
+				NextToken:   token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEksAPI, target ResourceARN) (string, *eks.ListFargateProfilesOutput, error) {
+			cluster, name, err := eksNamesOfARN(target)
+			if err != nil {
+				return "", nil, err
+			}
+			return cluster, &eks.ListFargateProfilesOutput{FargateProfileNames: []string{name}}, nil
+		},
+		items: func(output *eks.ListFargateProfilesOutput) ([]string, *string) {
+			return output.FargateProfileNames, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEksAPI, cluster string, names []string) ([]*types.FargateProfile, error) {
+			output, err := client.DescribeFargateProfile(ctx, &eks.DescribeFargateProfileInput{
+				ClusterName:        aws.String(cluster),
+				FargateProfileName: aws.String(names[0]),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []*types.FargateProfile{output.FargateProfile}, nil
+		},
+		batchSize: 1,
+		tags: func(_ context.Context, _ awsEksAPI, profile *types.FargateProfile) (map[string]string, error) {
+			return profile.Tags, nil
+		},
+		envelope: func(profile *types.FargateProfile) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(profile.FargateProfileArn),
+				ID:        aws.ToString(profile.FargateProfileArn),
+				Name:      aws.ToString(profile.FargateProfileName),
+				CreatedAt: profile.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqEksAPI) queryAddon(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsEksAPI, *eks.ListAddonsOutput, string, *types.Addon]{
+		service:  "eks",
+		resource: "addon",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  listEksClusterNames,
+		list: func(ctx context.Context, client awsEksAPI, cluster string, token *string) (*eks.ListAddonsOutput, error) {
+			return client.ListAddons(ctx, &eks.ListAddonsInput{
+				ClusterName: aws.String(cluster),
+				NextToken:   token,
+			})
+		},
+		lookup: func(_ context.Context, _ awsEksAPI, target ResourceARN) (string, *eks.ListAddonsOutput, error) {
+			cluster, name, err := eksNamesOfARN(target)
+			if err != nil {
+				return "", nil, err
+			}
+			return cluster, &eks.ListAddonsOutput{Addons: []string{name}}, nil
+		},
+		items: func(output *eks.ListAddonsOutput) ([]string, *string) {
+			return output.Addons, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsEksAPI, cluster string, names []string) ([]*types.Addon, error) {
+			output, err := client.DescribeAddon(ctx, &eks.DescribeAddonInput{
+				ClusterName: aws.String(cluster),
+				AddonName:   aws.String(names[0]),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []*types.Addon{output.Addon}, nil
+		},
+		batchSize: 1,
+		tags: func(_ context.Context, _ awsEksAPI, addon *types.Addon) (map[string]string, error) {
+			return addon.Tags, nil
+		},
+		envelope: func(addon *types.Addon) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(addon.AddonArn),
+				ID:        aws.ToString(addon.AddonArn),
+				Name:      aws.ToString(addon.AddonName),
+				CreatedAt: addon.CreatedAt,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func listEksClusterNames(ctx context.Context, client awsEksAPI) ([]string, error) {
+	var clusterNames []string
+
+	paginator := eks.NewListClustersPaginator(client, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusterNames = append(clusterNames, output.Clusters...)
+	}
+
+	return clusterNames, nil
+}
+
+// eksNamesOfARN returns the cluster and the name in the ARN of a resource of a cluster,
+// such as "nodegroup/cluster/name/uuid" of node groups.
+func eksNamesOfARN(target ResourceARN) (string, string, error) {
+	parts := strings.Split(target.ID, "/")
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("ARN does not include the cluster of the %s: '%s'", target.Type, target.String())
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestEksValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqEksAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate cluster resource",
+			api:      AwsresqEksAPI{},
+			resource: "cluster",
+			expected: true,
+		},
+		{
+			name:     "validate nodegroup resource",
+			api:      AwsresqEksAPI{},
+			resource: "nodegroup",
+			expected: true,
+		},
+		{
+			name:     "validate fargate-profile resource",
+			api:      AwsresqEksAPI{},
+			resource: "fargate-profile",
+			expected: true,
+		},
+		{
+			name:     "validate addon resource",
+			api:      AwsresqEksAPI{},
+			resource: "addon",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqEksAPI{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEksClusterQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsEksAPI(ctrl)
+
+	production := &types.Cluster{
+		Arn:     aws.String("arn:aws:eks:ap-northeast-1:012345678901:cluster/production"),
+		Name:    aws.String("production"),
+		Version: aws.String("1.28"),
+		ResourcesVpcConfig: &types.VpcConfigResponse{
+			EndpointPublicAccess:  false,
+			EndpointPrivateAccess: true,
+		},
+		Logging: &types.Logging{
+			ClusterLogging: []types.LogSetup{
+				{Enabled: aws.Bool(true), Types: []types.LogType{types.LogTypeApi, types.LogTypeAudit}},
+			},
+		},
+		Tags: map[string]string{"env": "prod"},
+	}
+	staging := &types.Cluster{
+		Arn:     aws.String("arn:aws:eks:ap-northeast-1:012345678901:cluster/staging"),
+		Name:    aws.String("staging"),
+		Version: aws.String("1.27"),
+		Tags:    map[string]string{"env": "stg"},
+	}
+
+	mc.EXPECT().
+		ListClusters(gomock.Any(), &eks.ListClustersInput{}).
+		Return(&eks.ListClustersOutput{
+			Clusters:  []string{"production"},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListClusters(gomock.Any(), &eks.ListClustersInput{NextToken: aws.String("next-token")}).
+		Return(&eks.ListClustersOutput{
+			Clusters: []string{"staging"},
+		}, nil).
+		AnyTimes()
+	for _, cluster := range []*types.Cluster{production, staging} {
+		mc.EXPECT().
+			DescribeCluster(gomock.Any(), &eks.DescribeClusterInput{Name: cluster.Name}).
+			Return(&eks.DescribeClusterOutput{Cluster: cluster}, nil).
+			AnyTimes()
+	}
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []interface{}
+	}{
+		{
+			name:     "query cluster resource",
+			expected: []interface{}{production, staging},
+		},
+		{
+			name:     "query cluster resource with tag filters",
+			opt:      QueryOption{TagFilters: TagFilters{{Key: "env", Value: "stg", HasValue: true}}},
+			expected: []interface{}{staging},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqEksAPI(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put("", "ap-northeast-1", "eks", mc)
+
+			actual, err := api.Query("cluster")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestEksNodegroupQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsEksAPI(ctrl)
+
+	production := &types.Nodegroup{
+		NodegroupArn:  aws.String("arn:aws:eks:ap-northeast-1:012345678901:nodegroup/production/default/0123abcd-4567-89ef-0123-456789abcdef"),
+		NodegroupName: aws.String("default"),
+		ClusterName:   aws.String("production"),
+		CapacityType:  types.CapacityTypesOnDemand,
+	}
+	staging := &types.Nodegroup{
+		NodegroupArn:  aws.String("arn:aws:eks:ap-northeast-1:012345678901:nodegroup/staging/spot/fedcba98-7654-3210-fedc-ba9876543210"),
+		NodegroupName: aws.String("spot"),
+		ClusterName:   aws.String("staging"),
+		CapacityType:  types.CapacityTypesSpot,
+	}
+
+	mc.EXPECT().
+		ListClusters(gomock.Any(), &eks.ListClustersInput{}).
+		Return(&eks.ListClustersOutput{
+			Clusters: []string{"production", "staging"},
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListNodegroups(gomock.Any(), &eks.ListNodegroupsInput{ClusterName: aws.String("production")}).
+		Return(&eks.ListNodegroupsOutput{Nodegroups: []string{"default"}}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListNodegroups(gomock.Any(), &eks.ListNodegroupsInput{ClusterName: aws.String("staging")}).
+		Return(&eks.ListNodegroupsOutput{Nodegroups: []string{"spot"}}, nil).
+		Times(1)
+	for _, nodegroup := range []*types.Nodegroup{production, staging} {
+		mc.EXPECT().
+			DescribeNodegroup(gomock.Any(), &eks.DescribeNodegroupInput{
+				ClusterName:   nodegroup.ClusterName,
+				NodegroupName: nodegroup.NodegroupName,
+			}).
+			Return(&eks.DescribeNodegroupOutput{Nodegroup: nodegroup}, nil).
+			Times(1)
+	}
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqEksAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+	api.clients.put("", "ap-northeast-1", "eks", mc)
+
+	actual, err := api.Query("nodegroup")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []interface{}{production, staging}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+}
+
+func TestEksQueryByARN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsEksAPI(ctrl)
+
+	profile := &types.FargateProfile{
+		FargateProfileArn:  aws.String("arn:aws:eks:ap-northeast-1:012345678901:fargateprofile/production/batch/0123abcd-4567-89ef-0123-456789abcdef"),
+		FargateProfileName: aws.String("batch"),
+		ClusterName:        aws.String("production"),
+		Selectors:          []types.FargateProfileSelector{{Namespace: aws.String("batch")}},
+	}
+	addon := &types.Addon{
+		AddonArn:     aws.String("arn:aws:eks:ap-northeast-1:012345678901:addon/production/vpc-cni/0123abcd-4567-89ef-0123-456789abcdef"),
+		AddonName:    aws.String("vpc-cni"),
+		AddonVersion: aws.String("v1.15.4-eksbuild.1"),
+		ClusterName:  aws.String("production"),
+	}
+
+	mc.EXPECT().
+		DescribeFargateProfile(gomock.Any(), &eks.DescribeFargateProfileInput{
+			ClusterName:        aws.String("production"),
+			FargateProfileName: aws.String("batch"),
+		}).
+		Return(&eks.DescribeFargateProfileOutput{FargateProfile: profile}, nil).
+		Times(1)
+	mc.EXPECT().
+		DescribeAddon(gomock.Any(), &eks.DescribeAddonInput{
+			ClusterName: aws.String("production"),
+			AddonName:   aws.String("vpc-cni"),
+		}).
+		Return(&eks.DescribeAddonOutput{Addon: addon}, nil).
+		Times(1)
+
+	cases := []struct {
+		name     string
+		arn      string
+		expected interface{}
+	}{
+		{
+			name:     "describe fargate-profile in the cluster of the arn",
+			arn:      aws.ToString(profile.FargateProfileArn),
+			expected: profile,
+		},
+		{
+			name:     "describe addon in the cluster of the arn",
+			arn:      aws.ToString(addon.AddonArn),
+			expected: addon,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceARN, err := ParseResourceARN(tt.arn)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqEksAPI(config, []string{"ap-northeast-1"}, QueryOption{ARN: &resourceARN})
+			api.clients.put("", "ap-northeast-1", "eks", mc)
+
+			actual, err := api.Query(resourceARN.Resource)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			expected := []interface{}{tt.expected}
+			if !reflect.DeepEqual(actual.Results, expected) {
+				t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/rs/zerolog/log"
+)
+
+type awsElbAPI interface {
+	DescribeLoadBalancers(ctx context.Context, params *elasticloadbalancing.DescribeLoadBalancersInput, optFns ...func(*elasticloadbalancing.Options)) (*elasticloadbalancing.DescribeLoadBalancersOutput, error)
+	DescribeTags(ctx context.Context, params *elasticloadbalancing.DescribeTagsInput, optFns ...func(*elasticloadbalancing.Options)) (*elasticloadbalancing.DescribeTagsOutput, error)
+}
+
+var elbService = register(ServiceDefinition{
+	Name:         "elb",
+	Description:  "Elastic Load Balancing of classic load balancers",
+	ARNNamespace: "elasticloadbalancing",
+	Resources: []ResourceDefinition{
+		newResource("load-balancer", "classic load balancers", []string{"LoadBalancerName", "Scheme", "DNSName", "VPCId"},
+			func(api *AwsresqElbAPI) ResourceQueryAPI { return api.queryLoadBalancer }).withARN("loadbalancer"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqElbAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqElbAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqElbAPI(c aws.Config, region []string, opt QueryOption) *AwsresqElbAPI {
+	return &AwsresqElbAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqElbAPI) Validate(resource string) bool {
+	return elbService.Validate(resource)
+}
+
+func (api *AwsresqElbAPI) DefaultColumns(resource string) []string {
+	return elbService.DefaultColumns(resource)
+}
+
+func (api *AwsresqElbAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqElbAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return elbService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqElbAPI) client(region string) awsElbAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "elb", func() awsElbAPI {
+		return elasticloadbalancing.NewFromConfig(api.awsCfg, func(o *elasticloadbalancing.Options) {
+			o.Region = region
+		})
+	})
+}
+
+// account returns the account of the load balancers in region, which DescribeLoadBalancers does not return:
+// the account of the ARN looked up, of the query in multi-account mode, or else of the credentials.
+func (api *AwsresqElbAPI) account(ctx context.Context, region string) (string, error) {
+	if api.opt.ARN != nil {
+		return api.opt.ARN.AccountID, nil
+	}
+	if api.opt.Account != "" {
+		return api.opt.Account, nil
+	}
+	client := cachedClient(api.clients, api.opt.Account, region, "sts", func() awsCallerIdentityAPI {
+		return sts.NewFromConfig(api.awsCfg, func(o *sts.Options) {
+			o.Region = region
+		})
+	})
+	return lookupCallerAccount(ctx, client)
+}
+
+func (api *AwsresqElbAPI) queryLoadBalancer(ctx context.Context, ch chan ResultList, region string) {
+	// the account is only needed to build the ARNs of the envelopes
+	var account string
+	if api.opt.Envelope {
+		var err error
+		account, err = api.account(ctx, region)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to look up the account of elb load-balancer in %s", region)
+			resultList := ResultList{Service: "elb", Resource: "load-balancer"}
+			resultList.addError(region, err)
+			ch <- resultList
+			return
+		}
+	}
+
+	resourceQuery[awsElbAPI, *elasticloadbalancing.DescribeLoadBalancersOutput, types.LoadBalancerDescription, types.LoadBalancerDescription]{
+		service:  "elb",
+		resource: "load-balancer",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsElbAPI, _ string, token *string) (*elasticloadbalancing.DescribeLoadBalancersOutput, error) {
+			return client.DescribeLoadBalancers(ctx, &elasticloadbalancing.DescribeLoadBalancersInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsElbAPI, target ResourceARN) (string, *elasticloadbalancing.DescribeLoadBalancersOutput, error) {
+			output, err := client.DescribeLoadBalancers(ctx, &elasticloadbalancing.DescribeLoadBalancersInput{
+				LoadBalancerNames: []string{target.ID},
+			})
+			return "", output, err
+		},
+		items: func(output *elasticloadbalancing.DescribeLoadBalancersOutput) ([]types.LoadBalancerDescription, *string) {
+			return output.LoadBalancerDescriptions, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsElbAPI, lb types.LoadBalancerDescription) (map[string]string, error) {
+			output, err := client.DescribeTags(ctx, &elasticloadbalancing.DescribeTagsInput{
+				LoadBalancerNames: []string{aws.ToString(lb.LoadBalancerName)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			var tags []types.Tag
+			for _, description := range output.TagDescriptions {
+				tags = append(tags, description.Tags...)
+			}
+			return elbTagMap(tags), nil
+		},
+		envelope: func(lb types.LoadBalancerDescription) Envelope {
+			return Envelope{
+				ARN:       api.opt.Partition.ARN("elasticloadbalancing", region, account, "loadbalancer/"+aws.ToString(lb.LoadBalancerName)),
+				ID:        aws.ToString(lb.LoadBalancerName),
+				Name:      aws.ToString(lb.LoadBalancerName),
+				CreatedAt: lb.CreatedTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func elbTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
+}
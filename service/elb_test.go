@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestElbValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqElbAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate load-balancer resource",
+			api:      AwsresqElbAPI{},
+			resource: "load-balancer",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqElbAPI{},
+			resource: "target-group",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestElbLoadBalancerQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbAPI(ctrl)
+	ms := mock_service.NewMockawsCallerIdentityAPI(ctrl)
+
+	// the owner of the source security group is not the account of the load balancer
+	legacy := types.LoadBalancerDescription{
+		LoadBalancerName: aws.String("legacy"),
+		Scheme:           aws.String("internet-facing"),
+		SourceSecurityGroup: &types.SourceSecurityGroup{
+			GroupName:  aws.String("default_elb_0123abcd"),
+			OwnerAlias: aws.String("amazon-elb"),
+		},
+	}
+	internal := types.LoadBalancerDescription{
+		LoadBalancerName: aws.String("internal"),
+		Scheme:           aws.String("internal"),
+	}
+
+	mc.EXPECT().
+		DescribeLoadBalancers(gomock.Any(), &elasticloadbalancing.DescribeLoadBalancersInput{}).
+		Return(&elasticloadbalancing.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: []types.LoadBalancerDescription{legacy, internal},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeLoadBalancers(gomock.Any(), &elasticloadbalancing.DescribeLoadBalancersInput{
+			LoadBalancerNames: []string{"legacy"},
+		}).
+		Return(&elasticloadbalancing.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: []types.LoadBalancerDescription{legacy},
+		}, nil).
+		AnyTimes()
+	for _, name := range []string{"legacy", "internal"} {
+		mc.EXPECT().
+			DescribeTags(gomock.Any(), &elasticloadbalancing.DescribeTagsInput{LoadBalancerNames: []string{name}}).
+			Return(&elasticloadbalancing.DescribeTagsOutput{
+				TagDescriptions: []types.TagDescription{
+					{
+						LoadBalancerName: aws.String(name),
+						Tags:             []types.Tag{{Key: aws.String("name"), Value: aws.String(name)}},
+					},
+				},
+			}, nil).
+			AnyTimes()
+	}
+	// the account of the credentials is only looked up when listing the load balancers in a single account
+	ms.EXPECT().
+		GetCallerIdentity(gomock.Any(), &sts.GetCallerIdentityInput{}).
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("012345678901")}, nil).
+		Times(1)
+
+	resourceARN, err := ParseResourceARN("arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:loadbalancer/legacy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	envelope := func(account string, lb types.LoadBalancerDescription) Envelope {
+		name := aws.ToString(lb.LoadBalancerName)
+		return Envelope{
+			ARN:      "arn:aws:elasticloadbalancing:ap-northeast-1:" + account + ":loadbalancer/" + name,
+			ID:       name,
+			Name:     name,
+			Region:   "ap-northeast-1",
+			Account:  account,
+			Service:  "elb",
+			Resource: "load-balancer",
+			Tags:     map[string]string{"name": name},
+			Raw:      lb,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []interface{}
+	}{
+		{
+			name: "query load-balancer resource in the account of the credentials",
+			opt:  QueryOption{Envelope: true},
+			expected: []interface{}{
+				envelope("012345678901", legacy),
+				envelope("012345678901", internal),
+			},
+		},
+		{
+			name: "query load-balancer resource in the account of the query",
+			opt:  QueryOption{Envelope: true, Account: "123456789012"},
+			expected: []interface{}{
+				envelope("123456789012", legacy),
+				envelope("123456789012", internal),
+			},
+		},
+		{
+			name:     "query load-balancer resource by arn",
+			opt:      QueryOption{Envelope: true, ARN: &resourceARN},
+			expected: []interface{}{envelope("012345678901", legacy)},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqElbAPI(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put(tt.opt.Account, "ap-northeast-1", "elb", mc)
+			api.clients.put(tt.opt.Account, "ap-northeast-1", "sts", ms)
+
+			actual, err := api.Query("load-balancer")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestElbLoadBalancerQueryWithoutCallerAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbAPI(ctrl)
+	ms := mock_service.NewMockawsCallerIdentityAPI(ctrl)
+
+	ms.EXPECT().
+		GetCallerIdentity(gomock.Any(), &sts.GetCallerIdentityInput{}).
+		Return(nil, errors.New("access denied")).
+		Times(1)
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqElbAPI(config, []string{"ap-northeast-1"}, QueryOption{Envelope: true})
+	api.clients.put("", "ap-northeast-1", "elb", mc)
+	api.clients.put("", "ap-northeast-1", "sts", ms)
+
+	_, err := api.Query("load-balancer")
+	if !errors.Is(err, ErrQueryFailed) {
+		t.Errorf("expected %v, but got %v", ErrQueryFailed, err)
+	}
+}
@@ -0,0 +1,349 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+type awsElbv2API interface {
+	DescribeLoadBalancers(ctx context.Context, params *elasticloadbalancingv2.DescribeLoadBalancersInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error)
+	DescribeTargetGroups(ctx context.Context, params *elasticloadbalancingv2.DescribeTargetGroupsInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeTargetGroupsOutput, error)
+	DescribeListeners(ctx context.Context, params *elasticloadbalancingv2.DescribeListenersInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeListenersOutput, error)
+	DescribeRules(ctx context.Context, params *elasticloadbalancingv2.DescribeRulesInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeRulesOutput, error)
+	DescribeTargetHealth(ctx context.Context, params *elasticloadbalancingv2.DescribeTargetHealthInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeTargetHealthOutput, error)
+	DescribeTags(ctx context.Context, params *elasticloadbalancingv2.DescribeTagsInput, optFns ...func(*elasticloadbalancingv2.Options)) (*elasticloadbalancingv2.DescribeTagsOutput, error)
+}
+
+var elbv2Service = register(ServiceDefinition{
+	Name:         "elbv2",
+	Description:  "Elastic Load Balancing of application, network and gateway load balancers",
+	ARNNamespace: "elasticloadbalancing",
+	Resources: []ResourceDefinition{
+		// the types include the kind of load balancer, as classic load balancers of elb have the type "loadbalancer" alone
+		newResource("load-balancer", "application, network and gateway load balancers", []string{"LoadBalancerName", "Type", "Scheme", "State.Code", "DNSName"},
+			func(api *AwsresqElbv2API) ResourceQueryAPI { return api.queryLoadBalancer }).
+			withARN("loadbalancer/app", "loadbalancer/net", "loadbalancer/gwy"),
+		newResource("target-group", "target groups and the load balancers forwarding to them", []string{"TargetGroupName", "TargetType", "Protocol", "Port", "LoadBalancerArns"},
+			func(api *AwsresqElbv2API) ResourceQueryAPI { return api.queryTargetGroup }).withARN("targetgroup"),
+		newResource("listener", "listeners of every load balancer", []string{"ListenerArn", "Protocol", "Port", "DefaultActions.Type"},
+			func(api *AwsresqElbv2API) ResourceQueryAPI { return api.queryListener }).withARN("listener"),
+		newResource("rule", "rules of every listener", []string{"RuleArn", "Priority", "Conditions.Field", "Actions.Type"},
+			func(api *AwsresqElbv2API) ResourceQueryAPI { return api.queryRule }).withARN("listener-rule"),
+		newResource("target-health", "health of the targets registered to every target group", []string{"TargetGroupArn", "Target.Id", "Target.Port", "TargetHealth.State", "TargetHealth.Reason"},
+			func(api *AwsresqElbv2API) ResourceQueryAPI { return api.queryTargetHealth }),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqElbv2API(cfg, region, opt)
+	},
+})
+
+type AwsresqElbv2API struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqElbv2API(c aws.Config, region []string, opt QueryOption) *AwsresqElbv2API {
+	return &AwsresqElbv2API{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqElbv2API) Validate(resource string) bool {
+	return elbv2Service.Validate(resource)
+}
+
+func (api *AwsresqElbv2API) DefaultColumns(resource string) []string {
+	return elbv2Service.DefaultColumns(resource)
+}
+
+func (api *AwsresqElbv2API) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqElbv2API) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return elbv2Service.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqElbv2API) client(region string) awsElbv2API {
+	return cachedClient(api.clients, api.opt.Account, region, "elbv2", func() awsElbv2API {
+		return elasticloadbalancingv2.NewFromConfig(api.awsCfg, func(o *elasticloadbalancingv2.Options) {
+			o.Region = region
+		})
+	})
+}
+
+// elbv2TargetHealth is the health of a target along with the target group it is registered to,
+// which DescribeTargetHealth does not return.
+type elbv2TargetHealth struct {
+	TargetGroupArn *string
+	types.TargetHealthDescription
+}
+
+func (api *AwsresqElbv2API) queryLoadBalancer(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsElbv2API, *elasticloadbalancingv2.DescribeLoadBalancersOutput, types.LoadBalancer, types.LoadBalancer]{
+		service:  "elbv2",
+		resource: "load-balancer",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsElbv2API, _ string, token *string) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
+			return client.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsElbv2API, target ResourceARN) (string, *elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
+			output, err := client.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{
+				LoadBalancerArns: []string{target.String()},
+			})
+			return "", output, err
+		},
+		items: func(output *elasticloadbalancingv2.DescribeLoadBalancersOutput) ([]types.LoadBalancer, *string) {
+			return output.LoadBalancers, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsElbv2API, lb types.LoadBalancer) (map[string]string, error) {
+			return elbv2ListTags(ctx, client, lb.LoadBalancerArn)
+		},
+		envelope: func(lb types.LoadBalancer) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(lb.LoadBalancerArn),
+				ID:        aws.ToString(lb.LoadBalancerArn),
+				Name:      aws.ToString(lb.LoadBalancerName),
+				CreatedAt: lb.CreatedTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqElbv2API) queryTargetGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsElbv2API, *elasticloadbalancingv2.DescribeTargetGroupsOutput, types.TargetGroup, types.TargetGroup]{
+		service:  "elbv2",
+		resource: "target-group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsElbv2API, _ string, token *string) (*elasticloadbalancingv2.DescribeTargetGroupsOutput, error) {
+			return client.DescribeTargetGroups(ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsElbv2API, target ResourceARN) (string, *elasticloadbalancingv2.DescribeTargetGroupsOutput, error) {
+			output, err := client.DescribeTargetGroups(ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{
+				TargetGroupArns: []string{target.String()},
+			})
+			return "", output, err
+		},
+		items: func(output *elasticloadbalancingv2.DescribeTargetGroupsOutput) ([]types.TargetGroup, *string) {
+			return output.TargetGroups, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsElbv2API, tg types.TargetGroup) (map[string]string, error) {
+			return elbv2ListTags(ctx, client, tg.TargetGroupArn)
+		},
+		envelope: func(tg types.TargetGroup) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(tg.TargetGroupArn),
+				ID:   aws.ToString(tg.TargetGroupArn),
+				Name: aws.ToString(tg.TargetGroupName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqElbv2API) queryListener(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsElbv2API, *elasticloadbalancingv2.DescribeListenersOutput, types.Listener, types.Listener]{
+		service:  "elbv2",
+		resource: "listener",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  elbv2ListLoadBalancerArns,
+		list: func(ctx context.Context, client awsElbv2API, lb string, token *string) (*elasticloadbalancingv2.DescribeListenersOutput, error) {
+			return client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{
+				LoadBalancerArn: aws.String(lb),
+				Marker:          token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsElbv2API, target ResourceARN) (string, *elasticloadbalancingv2.DescribeListenersOutput, error) {
+			output, err := client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{
+				ListenerArns: []string{target.String()},
+			})
+			return "", output, err
+		},
+		items: func(output *elasticloadbalancingv2.DescribeListenersOutput) ([]types.Listener, *string) {
+			return output.Listeners, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsElbv2API, listener types.Listener) (map[string]string, error) {
+			return elbv2ListTags(ctx, client, listener.ListenerArn)
+		},
+		envelope: func(listener types.Listener) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(listener.ListenerArn),
+				ID:   aws.ToString(listener.ListenerArn),
+				Name: fmt.Sprintf("%s:%d", listener.Protocol, aws.ToInt32(listener.Port)),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqElbv2API) queryRule(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsElbv2API, *elasticloadbalancingv2.DescribeRulesOutput, types.Rule, types.Rule]{
+		service:  "elbv2",
+		resource: "rule",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  elbv2ListListenerArns,
+		list: func(ctx context.Context, client awsElbv2API, listener string, token *string) (*elasticloadbalancingv2.DescribeRulesOutput, error) {
+			return client.DescribeRules(ctx, &elasticloadbalancingv2.DescribeRulesInput{
+				ListenerArn: aws.String(listener),
+				Marker:      token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsElbv2API, target ResourceARN) (string, *elasticloadbalancingv2.DescribeRulesOutput, error) {
+			output, err := client.DescribeRules(ctx, &elasticloadbalancingv2.DescribeRulesInput{
+				RuleArns: []string{target.String()},
+			})
+			return "", output, err
+		},
+		items: func(output *elasticloadbalancingv2.DescribeRulesOutput) ([]types.Rule, *string) {
+			return output.Rules, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsElbv2API, rule types.Rule) (map[string]string, error) {
+			return elbv2ListTags(ctx, client, rule.RuleArn)
+		},
+		envelope: func(rule types.Rule) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(rule.RuleArn),
+				ID:   aws.ToString(rule.RuleArn),
+				Name: aws.ToString(rule.Priority),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqElbv2API) queryTargetHealth(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsElbv2API, *elasticloadbalancingv2.DescribeTargetHealthOutput, types.TargetHealthDescription, elbv2TargetHealth]{
+		service:  "elbv2",
+		resource: "target-health",
+		opt:      api.opt,
+		client:   api.client,
+		parents:  elbv2ListTargetGroupArns,
+		list: func(ctx context.Context, client awsElbv2API, tg string, _ *string) (*elasticloadbalancingv2.DescribeTargetHealthOutput, error) {
+			return client.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(tg),
+			})
+		},
+		items: func(output *elasticloadbalancingv2.DescribeTargetHealthOutput) ([]types.TargetHealthDescription, *string) {
+			return output.TargetHealthDescriptions, nil
+		},
+		describe: func(_ context.Context, _ awsElbv2API, tg string, descriptions []types.TargetHealthDescription) ([]elbv2TargetHealth, error) {
+			results := make([]elbv2TargetHealth, 0, len(descriptions))
+			for _, description := range descriptions {
+				results = append(results, elbv2TargetHealth{
+					TargetGroupArn:          aws.String(tg),
+					TargetHealthDescription: description,
+				})
+			}
+			return results, nil
+		},
+		envelope: func(health elbv2TargetHealth) Envelope {
+			var id string
+			if health.Target != nil {
+				id = aws.ToString(health.Target.Id)
+			}
+			return Envelope{
+				ID:   id,
+				Name: elbv2TargetGroupName(aws.ToString(health.TargetGroupArn)),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func elbv2ListLoadBalancerArns(ctx context.Context, client awsElbv2API) ([]string, error) {
+	var lbArns []string
+
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(client, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range output.LoadBalancers {
+			lbArns = append(lbArns, aws.ToString(lb.LoadBalancerArn))
+		}
+	}
+
+	return lbArns, nil
+}
+
+// elbv2ListListenerArns lists the listeners of every load balancer, as DescribeListeners requires either.
+func elbv2ListListenerArns(ctx context.Context, client awsElbv2API) ([]string, error) {
+	lbArns, err := elbv2ListLoadBalancerArns(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var listenerArns []string
+	for _, lbArn := range lbArns {
+		paginator := elasticloadbalancingv2.NewDescribeListenersPaginator(client, &elasticloadbalancingv2.DescribeListenersInput{
+			LoadBalancerArn: aws.String(lbArn),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, listener := range output.Listeners {
+				listenerArns = append(listenerArns, aws.ToString(listener.ListenerArn))
+			}
+		}
+	}
+
+	return listenerArns, nil
+}
+
+func elbv2ListTargetGroupArns(ctx context.Context, client awsElbv2API) ([]string, error) {
+	var tgArns []string
+
+	paginator := elasticloadbalancingv2.NewDescribeTargetGroupsPaginator(client, &elasticloadbalancingv2.DescribeTargetGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, tg := range output.TargetGroups {
+			tgArns = append(tgArns, aws.ToString(tg.TargetGroupArn))
+		}
+	}
+
+	return tgArns, nil
+}
+
+// elbv2TargetGroupName returns the name in the ARN of a target group, which ends with "targetgroup/name/id".
+func elbv2TargetGroupName(s string) string {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return s
+	}
+	return parts[1]
+}
+
+func elbv2ListTags(ctx context.Context, client awsElbv2API, arn *string) (map[string]string, error) {
+	output, err := client.DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{
+		ResourceArns: []string{aws.ToString(arn)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tags []types.Tag
+	for _, description := range output.TagDescriptions {
+		tags = append(tags, description.Tags...)
+	}
+	return elbv2TagMap(tags), nil
+}
+
+func elbv2TagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
+}
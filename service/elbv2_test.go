@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+const (
+	elbv2WebArn      = "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:loadbalancer/app/web/0123456789abcdef"
+	elbv2InternalArn = "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:loadbalancer/net/internal/fedcba9876543210"
+	elbv2ListenerArn = "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:listener/app/web/0123456789abcdef/0000000000000001"
+	elbv2TargetArn   = "arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:targetgroup/web/0123456789abcdef"
+)
+
+func TestElbv2Validate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqElbv2API
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate load-balancer resource",
+			api:      AwsresqElbv2API{},
+			resource: "load-balancer",
+			expected: true,
+		},
+		{
+			name:     "validate target-group resource",
+			api:      AwsresqElbv2API{},
+			resource: "target-group",
+			expected: true,
+		},
+		{
+			name:     "validate listener resource",
+			api:      AwsresqElbv2API{},
+			resource: "listener",
+			expected: true,
+		},
+		{
+			name:     "validate rule resource",
+			api:      AwsresqElbv2API{},
+			resource: "rule",
+			expected: true,
+		},
+		{
+			name:     "validate target-health resource",
+			api:      AwsresqElbv2API{},
+			resource: "target-health",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqElbv2API{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestElbv2LoadBalancerQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbv2API(ctrl)
+
+	createdAt := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	web := types.LoadBalancer{
+		LoadBalancerArn:  aws.String(elbv2WebArn),
+		LoadBalancerName: aws.String("web"),
+		Type:             types.LoadBalancerTypeEnumApplication,
+		CreatedTime:      &createdAt,
+	}
+	internal := types.LoadBalancer{
+		LoadBalancerArn:  aws.String(elbv2InternalArn),
+		LoadBalancerName: aws.String("internal"),
+		Type:             types.LoadBalancerTypeEnumNetwork,
+	}
+
+	mc.EXPECT().
+		DescribeLoadBalancers(gomock.Any(), &elasticloadbalancingv2.DescribeLoadBalancersInput{}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []types.LoadBalancer{web},
+			NextMarker:    aws.String("next-marker"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeLoadBalancers(gomock.Any(), &elasticloadbalancingv2.DescribeLoadBalancersInput{Marker: aws.String("next-marker")}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []types.LoadBalancer{internal},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeTags(gomock.Any(), &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: []string{elbv2WebArn}}).
+		Return(&elasticloadbalancingv2.DescribeTagsOutput{
+			TagDescriptions: []types.TagDescription{
+				{
+					ResourceArn: aws.String(elbv2WebArn),
+					Tags:        []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeTags(gomock.Any(), &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: []string{elbv2InternalArn}}).
+		Return(&elasticloadbalancingv2.DescribeTagsOutput{
+			TagDescriptions: []types.TagDescription{{ResourceArn: aws.String(elbv2InternalArn)}},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []interface{}
+	}{
+		{
+			name:     "query load-balancer resource",
+			expected: []interface{}{web, internal},
+		},
+		{
+			name: "query load-balancer resource with envelope",
+			opt: QueryOption{
+				Envelope:   true,
+				TagFilters: TagFilters{{Key: "env", Value: "prod", HasValue: true}},
+			},
+			expected: []interface{}{
+				Envelope{
+					ARN:       elbv2WebArn,
+					ID:        elbv2WebArn,
+					Name:      "web",
+					Region:    "ap-northeast-1",
+					Account:   "012345678901",
+					Service:   "elbv2",
+					Resource:  "load-balancer",
+					Tags:      map[string]string{"env": "prod"},
+					CreatedAt: &createdAt,
+					Raw:       web,
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqElbv2API(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put("", "ap-northeast-1", "elbv2", mc)
+
+			actual, err := api.Query("load-balancer")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestElbv2TargetGroupQueryByARN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbv2API(ctrl)
+
+	tg := types.TargetGroup{
+		TargetGroupArn:   aws.String(elbv2TargetArn),
+		TargetGroupName:  aws.String("web"),
+		LoadBalancerArns: []string{elbv2WebArn},
+	}
+	mc.EXPECT().
+		DescribeTargetGroups(gomock.Any(), &elasticloadbalancingv2.DescribeTargetGroupsInput{
+			TargetGroupArns: []string{elbv2TargetArn},
+		}).
+		Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+			TargetGroups: []types.TargetGroup{tg},
+		}, nil).
+		Times(1)
+
+	resourceARN, err := ParseResourceARN(elbv2TargetArn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqElbv2API(config, []string{"ap-northeast-1"}, QueryOption{ARN: &resourceARN})
+	api.clients.put("", "ap-northeast-1", "elbv2", mc)
+
+	actual, err := api.Query("target-group")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []interface{}{tg}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+}
+
+func TestElbv2ListenerAndRuleQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbv2API(ctrl)
+
+	listener := types.Listener{
+		ListenerArn:     aws.String(elbv2ListenerArn),
+		LoadBalancerArn: aws.String(elbv2WebArn),
+		Protocol:        types.ProtocolEnumHttps,
+		Port:            aws.Int32(443),
+	}
+	rules := []types.Rule{
+		{
+			RuleArn:  aws.String("arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:listener-rule/app/web/0123456789abcdef/0000000000000001/0000000000000001"),
+			Priority: aws.String("1"),
+		},
+		{
+			RuleArn:   aws.String("arn:aws:elasticloadbalancing:ap-northeast-1:012345678901:listener-rule/app/web/0123456789abcdef/0000000000000001/0000000000000002"),
+			Priority:  aws.String("default"),
+			IsDefault: aws.Bool(true),
+		},
+	}
+
+	mc.EXPECT().
+		DescribeLoadBalancers(gomock.Any(), &elasticloadbalancingv2.DescribeLoadBalancersInput{}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []types.LoadBalancer{
+				{LoadBalancerArn: aws.String(elbv2WebArn)},
+				{LoadBalancerArn: aws.String(elbv2InternalArn)},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeListeners(gomock.Any(), &elasticloadbalancingv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbv2WebArn)}).
+		Return(&elasticloadbalancingv2.DescribeListenersOutput{
+			Listeners: []types.Listener{listener},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeListeners(gomock.Any(), &elasticloadbalancingv2.DescribeListenersInput{LoadBalancerArn: aws.String(elbv2InternalArn)}).
+		Return(&elasticloadbalancingv2.DescribeListenersOutput{}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeRules(gomock.Any(), &elasticloadbalancingv2.DescribeRulesInput{ListenerArn: aws.String(elbv2ListenerArn)}).
+		Return(&elasticloadbalancingv2.DescribeRulesOutput{
+			Rules: rules,
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		resource string
+		expected []interface{}
+	}{
+		{
+			name:     "query listener resource of every load balancer",
+			resource: "listener",
+			expected: []interface{}{listener},
+		},
+		{
+			name:     "query rule resource of every listener",
+			resource: "rule",
+			expected: []interface{}{rules[0], rules[1]},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqElbv2API(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "elbv2", mc)
+
+			actual, err := api.Query(tt.resource)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestElbv2TargetHealthQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsElbv2API(ctrl)
+
+	healthy := types.TargetHealthDescription{
+		Target:       &types.TargetDescription{Id: aws.String("10.0.0.10"), Port: aws.Int32(8080)},
+		TargetHealth: &types.TargetHealth{State: types.TargetHealthStateEnumHealthy},
+	}
+	unhealthy := types.TargetHealthDescription{
+		Target: &types.TargetDescription{Id: aws.String("10.0.0.11"), Port: aws.Int32(8080)},
+		TargetHealth: &types.TargetHealth{
+			State:  types.TargetHealthStateEnumUnhealthy,
+			Reason: types.TargetHealthReasonEnumFailedHealthChecks,
+		},
+	}
+
+	mc.EXPECT().
+		DescribeTargetGroups(gomock.Any(), &elasticloadbalancingv2.DescribeTargetGroupsInput{}).
+		Return(&elasticloadbalancingv2.DescribeTargetGroupsOutput{
+			TargetGroups: []types.TargetGroup{{TargetGroupArn: aws.String(elbv2TargetArn)}},
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		DescribeTargetHealth(gomock.Any(), &elasticloadbalancingv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(elbv2TargetArn)}).
+		Return(&elasticloadbalancingv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []types.TargetHealthDescription{healthy, unhealthy},
+		}, nil).
+		Times(1)
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqElbv2API(config, []string{"ap-northeast-1"}, QueryOption{Envelope: true})
+	api.clients.put("", "ap-northeast-1", "elbv2", mc)
+
+	actual, err := api.Query("target-health")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []interface{}{
+		Envelope{
+			ID:       "10.0.0.10",
+			Name:     "web",
+			Region:   "ap-northeast-1",
+			Service:  "elbv2",
+			Resource: "target-health",
+			Tags:     map[string]string{},
+			Raw:      elbv2TargetHealth{TargetGroupArn: aws.String(elbv2TargetArn), TargetHealthDescription: healthy},
+		},
+		Envelope{
+			ID:       "10.0.0.11",
+			Name:     "web",
+			Region:   "ap-northeast-1",
+			Service:  "elbv2",
+			Resource: "target-health",
+			Tags:     map[string]string{},
+			Raw:      elbv2TargetHealth{TargetGroupArn: aws.String(elbv2TargetArn), TargetHealthDescription: unhealthy},
+		},
+	}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+}
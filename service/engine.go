@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/rs/zerolog/log"
+)
+
+// resourceQuery declares how a resource is queried with the API client C,
+// so that every resource lists, describes, filters and reports errors the same way.
+// O is the output of the list API, I is the type of the listed items and R is the type of the results.
+type resourceQuery[C, O, I, R any] struct {
+	service  string
+	resource string
+	opt      QueryOption
+
+	// client returns the API client for the region.
+	client func(region string) C
+	// parents optionally lists the resources containing the resource, such as the clusters of ECS services.
+	// list and describe are called for each parent. Without parents, they are called once with an empty parent.
+	parents func(ctx context.Context, client C) ([]string, error)
+	// list calls the list API for the page following token, which is nil for the first page.
+	list func(ctx context.Context, client C, parent string, token *string) (O, error)
+	// lookup optionally calls a targeted API for the resource selected by the ARN option, instead of listing them all.
+	// It returns its output as a page of list along with the parent of the resource.
+	// Without lookup, every resource is listed and those of other ARNs are skipped.
+	lookup func(ctx context.Context, client C, target ResourceARN) (string, O, error)
+	// items extracts the listed items and the token of the next page from the output of list.
+	// A nil token means the last page.
+	items func(output O) ([]I, *string)
+	// describe optionally converts a batch of listed items into results, such as by calling a describe API.
+	// Without describe, the listed items are the results and R must be the same type as I.
+	describe func(ctx context.Context, client C, parent string, items []I) ([]R, error)
+	// batchSize is the maximum number of items passed to describe at once. Zero means a whole page.
+	batchSize int
+	// tags returns the tags of a result, which are matched against the tag filters and reported in the envelope.
+	// It is only called when tag filters are given or results are wrapped in envelopes.
+	tags func(ctx context.Context, client C, result R) (map[string]string, error)
+	// raw optionally returns the payload reported for a result. Without raw, the result itself is reported.
+	raw func(result R) interface{}
+	// envelope extracts the ARN, ID, name and creation time of a result, and its region and account when they
+	// are neither the region queried nor the account of the ARN. The other fields of the envelope are set by the query.
+	envelope func(result R) Envelope
+}
+
+// untagged returns the tags of resources which cannot be tagged.
+func untagged[C, R any](context.Context, C, R) (map[string]string, error) {
+	return nil, nil
+}
+
+// run queries the resource in region and reports the results on ch.
+// It always reports, with the error if the query failed, so that the caller never waits for the timeout.
+// Failing to list stops the query in the region, while failing to describe some items only skips them.
+// Failing to tag some items only skips them when the tags are needed by the tag filters.
+func (q resourceQuery[C, O, I, R]) run(ctx context.Context, ch chan ResultList, region string) {
+	resultList := ResultList{
+		Service:  q.service,
+		Resource: q.resource,
+	}
+	var ids []string
+	defer func() {
+		if q.opt.OrderByID {
+			sortByID(resultList.Results, ids)
+		}
+		ch <- resultList
+	}()
+
+	client := q.client(region)
+	if q.opt.ARN != nil && q.lookup != nil {
+		parent, output, err := q.lookup(ctx, client, *q.opt.ARN)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to look up %s %s in %s", q.service, q.resource, region)
+			resultList.addError(region, err)
+			return
+		}
+		items, _ := q.items(output)
+		q.collect(ctx, client, region, parent, items, &resultList, &ids)
+		return
+	}
+
+	parents := []string{""}
+	if q.parents != nil {
+		var err error
+		parents, err = q.parents(ctx, client)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed to list parents of %s %s in %s", q.service, q.resource, region)
+			resultList.addError(region, err)
+			return
+		}
+	}
+
+	for _, parent := range parents {
+		var token *string
+		for {
+			output, err := q.list(ctx, client, parent, token)
+			if err != nil {
+				log.Error().Err(err).Msgf("failed to list %s %s in %s", q.service, q.resource, region)
+				resultList.addError(region, err)
+				return
+			}
+			items, next := q.items(output)
+			q.collect(ctx, client, region, parent, items, &resultList, &ids)
+
+			// stop on the last page, or when the API returns the same token again
+			if next == nil || (token != nil && aws.ToString(next) == aws.ToString(token)) {
+				break
+			}
+			token = next
+		}
+	}
+}
+
+// collect converts the items of a page into results and adds those matching the tag filters to resultList.
+// The IDs of the results added are appended to ids when they are to be ordered by ID.
+func (q resourceQuery[C, O, I, R]) collect(ctx context.Context, client C, region, parent string, items []I, resultList *ResultList, ids *[]string) {
+	for _, batch := range batches(items, q.batchSize) {
+		var results []R
+		if q.describe == nil {
+			for _, item := range batch {
+				results = append(results, any(item).(R))
+			}
+		} else {
+			var err error
+			results, err = q.describe(ctx, client, parent, batch)
+			if err != nil {
+				log.Error().Err(err).Msgf("failed to describe %s %s in %s", q.service, q.resource, region)
+				resultList.addError(region, err)
+				continue
+			}
+		}
+
+		for _, result := range results {
+			if !q.selected(result) {
+				continue
+			}
+			var tags map[string]string
+			if q.tags != nil && (len(q.opt.TagFilters) > 0 || q.opt.Envelope) {
+				var err error
+				tags, err = q.tags(ctx, client, result)
+				if err != nil {
+					log.Error().Err(err).Msgf("failed to get tags of %s %s in %s", q.service, q.resource, region)
+					resultList.addError(region, err)
+					// the result is kept with empty tags unless the tags are needed to filter it
+					if len(q.opt.TagFilters) > 0 {
+						continue
+					}
+				}
+			}
+			if !q.opt.TagFilters.Match(tags) {
+				continue
+			}
+
+			var payload interface{} = result
+			if q.raw != nil {
+				payload = q.raw(result)
+			}
+			if q.opt.Envelope {
+				payload = q.wrap(result, payload, region, tags)
+			}
+			if q.opt.OrderByID && q.envelope != nil {
+				*ids = append(*ids, q.envelope(result).ID)
+			}
+			resultList.Results = append(resultList.Results, payload)
+		}
+	}
+}
+
+// sortByID orders results by ids, the IDs of the results at the same index, keeping the order of equal IDs.
+// Results are kept as they are when some have no ID.
+func sortByID(results []interface{}, ids []string) {
+	if len(ids) != len(results) {
+		return
+	}
+	sort.Stable(byID{results: results, ids: ids})
+}
+
+type byID struct {
+	results []interface{}
+	ids     []string
+}
+
+func (b byID) Len() int           { return len(b.ids) }
+func (b byID) Less(i, j int) bool { return b.ids[i] < b.ids[j] }
+func (b byID) Swap(i, j int) {
+	b.results[i], b.results[j] = b.results[j], b.results[i]
+	b.ids[i], b.ids[j] = b.ids[j], b.ids[i]
+}
+
+// selected reports whether result is the resource selected by the ARN option.
+// Every result is selected without the option, and so are the results of lookup.
+func (q resourceQuery[C, O, I, R]) selected(result R) bool {
+	if q.opt.ARN == nil || q.lookup != nil {
+		return true
+	}
+	return q.envelope != nil && q.envelope(result).ARN == q.opt.ARN.String()
+}
+
+// wrap wraps the payload of result in an envelope.
+func (q resourceQuery[C, O, I, R]) wrap(result R, payload interface{}, region string, tags map[string]string) Envelope {
+	var envelope Envelope
+	if q.envelope != nil {
+		envelope = q.envelope(result)
+	}
+	if envelope.Region == "" {
+		envelope.Region = region
+	}
+	if q.opt.Account != "" {
+		envelope.Account = q.opt.Account
+	}
+	if envelope.Account == "" {
+		envelope.Account = accountOfARN(envelope.ARN)
+	}
+	envelope.Service = q.service
+	envelope.Resource = q.resource
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	envelope.Tags = tags
+	envelope.Raw = payload
+	return envelope
+}
+
+// batches splits items into batches of at most size items. Zero size means a single batch.
+func batches[T any](items []T, size int) [][]T {
+	if len(items) == 0 {
+		return nil
+	}
+	if size <= 0 || size >= len(items) {
+		return [][]T{items}
+	}
+
+	var result [][]T
+	for size < len(items) {
+		result = append(result, items[:size])
+		items = items[size:]
+	}
+	return append(result, items)
+}
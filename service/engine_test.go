@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// enginePage is a page returned by the list function of the resource queries in tests.
+type enginePage struct {
+	items []string
+	next  *string
+}
+
+func TestResourceQueryRun(t *testing.T) {
+	pages := map[string]enginePage{
+		"":      {items: []string{"a", "b", "c"}, next: aws.String("page2")},
+		"page2": {items: []string{"d"}},
+	}
+	list := func(_ context.Context, pages map[string]enginePage, _ string, token *string) (enginePage, error) {
+		return pages[aws.ToString(token)], nil
+	}
+	items := func(output enginePage) ([]string, *string) {
+		return output.items, output.next
+	}
+
+	cases := []struct {
+		name           string
+		query          resourceQuery[map[string]enginePage, enginePage, string, string]
+		pages          map[string]enginePage
+		expected       []string
+		expectedErrors int
+	}{
+		{
+			name: "list every page",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				list:  list,
+				items: items,
+			},
+			pages:    pages,
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "order the results of every page by id",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:   QueryOption{OrderByID: true},
+				list:  list,
+				items: items,
+				envelope: func(result string) Envelope {
+					return Envelope{ID: result}
+				},
+			},
+			pages: map[string]enginePage{
+				"":      {items: []string{"d", "b"}, next: aws.String("page2")},
+				"page2": {items: []string{"c", "a"}},
+			},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "stop when the same token is returned again",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				list:  list,
+				items: items,
+			},
+			pages: map[string]enginePage{
+				"":      {items: []string{"a"}, next: aws.String("page2")},
+				"page2": {items: []string{"b"}, next: aws.String("page2")},
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "describe items in batches",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				list:  list,
+				items: items,
+				describe: func(_ context.Context, _ map[string]enginePage, _ string, items []string) ([]string, error) {
+					return []string{strings.Join(items, "")}, nil
+				},
+				batchSize: 2,
+			},
+			pages:    pages,
+			expected: []string{"ab", "c", "d"},
+		},
+		{
+			name: "skip batches failing to describe",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				list:  list,
+				items: items,
+				describe: func(_ context.Context, _ map[string]enginePage, _ string, items []string) ([]string, error) {
+					if items[0] == "b" {
+						return nil, errors.New("describe failed")
+					}
+					return items, nil
+				},
+				batchSize: 1,
+			},
+			pages:          pages,
+			expected:       []string{"a", "c", "d"},
+			expectedErrors: 1,
+		},
+		{
+			name: "list every parent",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				parents: func(context.Context, map[string]enginePage) ([]string, error) {
+					return []string{"x", "y"}, nil
+				},
+				list: func(_ context.Context, pages map[string]enginePage, parent string, token *string) (enginePage, error) {
+					return pages[parent+aws.ToString(token)], nil
+				},
+				items: items,
+				describe: func(_ context.Context, _ map[string]enginePage, parent string, items []string) ([]string, error) {
+					var results []string
+					for _, item := range items {
+						results = append(results, parent+"/"+item)
+					}
+					return results, nil
+				},
+			},
+			pages: map[string]enginePage{
+				"x":      {items: []string{"a"}, next: aws.String("page2")},
+				"xpage2": {items: []string{"b"}},
+				"y":      {items: []string{"c"}},
+			},
+			expected: []string{"x/a", "x/b", "y/c"},
+		},
+		{
+			name: "stop when parents cannot be listed",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				parents: func(context.Context, map[string]enginePage) ([]string, error) {
+					return nil, errors.New("list parents failed")
+				},
+				list:  list,
+				items: items,
+			},
+			pages:          pages,
+			expectedErrors: 1,
+		},
+		{
+			name: "stop when a page cannot be listed",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				list: func(_ context.Context, pages map[string]enginePage, _ string, token *string) (enginePage, error) {
+					if token != nil {
+						return enginePage{}, errors.New("list failed")
+					}
+					return pages[""], nil
+				},
+				items: items,
+			},
+			pages:          pages,
+			expected:       []string{"a", "b", "c"},
+			expectedErrors: 1,
+		},
+		{
+			name: "filter results by tags",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:   QueryOption{TagFilters: TagFilters{{Key: "env", Value: "prod", HasValue: true}}},
+				list:  list,
+				items: items,
+				tags: func(_ context.Context, _ map[string]enginePage, result string) (map[string]string, error) {
+					switch result {
+					case "a", "d":
+						return map[string]string{"env": "prod"}, nil
+					case "b":
+						return nil, errors.New("tags failed")
+					}
+					return map[string]string{"env": "dev"}, nil
+				},
+			},
+			pages:          pages,
+			expected:       []string{"a", "d"},
+			expectedErrors: 1,
+		},
+		{
+			name: "keep results whose tags cannot be fetched without tag filters",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:   QueryOption{Envelope: true},
+				list:  list,
+				items: items,
+				tags: func(_ context.Context, _ map[string]enginePage, result string) (map[string]string, error) {
+					if result == "b" {
+						return nil, errors.New("tags failed")
+					}
+					return map[string]string{"env": "prod"}, nil
+				},
+			},
+			pages:          pages,
+			expected:       []string{"a", "b", "c", "d"},
+			expectedErrors: 1,
+		},
+		{
+			name: "look up the resource of the arn instead of listing",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:  QueryOption{ARN: &ResourceARN{ID: "x"}},
+				list: list,
+				lookup: func(_ context.Context, _ map[string]enginePage, target ResourceARN) (string, enginePage, error) {
+					return "parent", enginePage{items: []string{target.ID}, next: aws.String("page2")}, nil
+				},
+				items: items,
+				describe: func(_ context.Context, _ map[string]enginePage, parent string, items []string) ([]string, error) {
+					return []string{parent + "/" + items[0]}, nil
+				},
+			},
+			pages:    pages,
+			expected: []string{"parent/x"},
+		},
+		{
+			name: "stop when the resource of the arn cannot be looked up",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:  QueryOption{ARN: &ResourceARN{ID: "x"}},
+				list: list,
+				lookup: func(context.Context, map[string]enginePage, ResourceARN) (string, enginePage, error) {
+					return "", enginePage{}, errors.New("lookup failed")
+				},
+				items: items,
+			},
+			pages:          pages,
+			expectedErrors: 1,
+		},
+		{
+			name: "select the resource of the arn while listing without lookup",
+			query: resourceQuery[map[string]enginePage, enginePage, string, string]{
+				opt:   QueryOption{ARN: &ResourceARN{ARN: arn.ARN{Partition: "aws", Service: "test", Resource: "c"}}},
+				list:  list,
+				items: items,
+				envelope: func(result string) Envelope {
+					return Envelope{ARN: "arn:aws:test:::" + result}
+				},
+			},
+			pages:    pages,
+			expected: []string{"c"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.client = func(string) map[string]enginePage { return tt.pages }
+
+			ch := make(chan ResultList, 1)
+			tt.query.run(context.Background(), ch, "ap-northeast-1")
+			resultList := <-ch
+
+			var results []string
+			for _, result := range resultList.Results {
+				if envelope, ok := result.(Envelope); ok {
+					result = envelope.Raw
+				}
+				results = append(results, result.(string))
+			}
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("expected %v, but got %v", tt.expected, results)
+			}
+			if len(resultList.Errors) != tt.expectedErrors {
+				t.Errorf("expected %v errors, but got %v", tt.expectedErrors, resultList.Errors)
+			}
+		})
+	}
+}
+
+func TestBatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		items    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "split into batches of size",
+			items:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:     "split evenly",
+			items:    []int{1, 2, 3, 4},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name:     "single batch for zero size",
+			items:    []int{1, 2, 3},
+			size:     0,
+			expected: [][]int{{1, 2, 3}},
+		},
+		{
+			name:     "no batch for no item",
+			items:    nil,
+			size:     2,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := batches(tt.items, tt.size)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
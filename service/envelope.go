@@ -0,0 +1,42 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// Envelope wraps a result in the fields common to every resource, so that results of different services
+// can be handled alike. The result itself is kept in Raw.
+type Envelope struct {
+	ARN     string `json:"arn"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Account string `json:"account"`
+	// AccountAlias is only set in multi-account mode.
+	AccountAlias string            `json:"accountAlias,omitempty"`
+	Service      string            `json:"service"`
+	Resource     string            `json:"resource"`
+	Tags         map[string]string `json:"tags"`
+	CreatedAt    *time.Time        `json:"createdAt"`
+	Raw          interface{}       `json:"raw"`
+}
+
+// accountOfARN returns the account ID in the ARN, or an empty string for ARNs without account or invalid ARNs.
+func accountOfARN(s string) string {
+	a, err := arn.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return a.AccountID
+}
+
+// unixMilli converts milliseconds since the epoch, as returned by some APIs, into a time.
+func unixMilli(msec *int64) *time.Time {
+	if msec == nil {
+		return nil
+	}
+	t := time.UnixMilli(*msec).UTC()
+	return &t
+}
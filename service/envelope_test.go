@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestEnvelopeQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	createdAt := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	mcEc2 := mock_service.NewMockawsEc2API(ctrl)
+	mcEc2.EXPECT().
+		DescribeInstances(gomock.Any(), &ec2.DescribeInstancesInput{}).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					OwnerId: aws.String("012345678901"),
+					Instances: []ec2types.Instance{
+						{
+							InstanceId: aws.String("i-1234567890abcdef0"),
+							LaunchTime: aws.Time(createdAt),
+							Tags: []ec2types.Tag{
+								{Key: aws.String("Name"), Value: aws.String("web")},
+							},
+						},
+					},
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	mcEcs := mock_service.NewMockawsEcsAPI(ctrl)
+	mcEcs.EXPECT().
+		ListTaskDefinitions(gomock.Any(), &ecs.ListTaskDefinitionsInput{}).
+		Return(&ecs.ListTaskDefinitionsOutput{
+			TaskDefinitionArns: []string{"arn:aws:ecs:ap-northeast-1:012345678901:task-definition/testapp:1"},
+		}, nil).
+		AnyTimes()
+	mcEcs.EXPECT().
+		DescribeTaskDefinition(gomock.Any(), gomock.Any()).
+		Return(&ecs.DescribeTaskDefinitionOutput{
+			Tags: []ecstypes.Tag{
+				{Key: aws.String("env"), Value: aws.String("prod")},
+			},
+			TaskDefinition: &ecstypes.TaskDefinition{
+				TaskDefinitionArn: aws.String("arn:aws:ecs:ap-northeast-1:012345678901:task-definition/testapp:1"),
+				Family:            aws.String("testapp"),
+				Revision:          1,
+				RegisteredAt:      aws.Time(createdAt),
+			},
+		}, nil).
+		AnyTimes()
+
+	mcIam := mock_service.NewMockawsIamAPI(ctrl)
+	mcIam.EXPECT().
+		ListRoles(gomock.Any(), &iam.ListRolesInput{}).
+		Return(&iam.ListRolesOutput{
+			Roles: []iamtypes.Role{
+				{
+					Arn:        aws.String("arn:aws:iam::012345678901:role/admin"),
+					RoleId:     aws.String("AROA0123456789EXAMPLE"),
+					RoleName:   aws.String("admin"),
+					CreateDate: aws.Time(createdAt),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcIam.EXPECT().
+		ListRoleTags(gomock.Any(), &iam.ListRoleTagsInput{RoleName: aws.String("admin")}).
+		Return(&iam.ListRoleTagsOutput{
+			Tags: []iamtypes.Tag{
+				{Key: aws.String("team"), Value: aws.String("infra")},
+			},
+		}, nil).
+		AnyTimes()
+
+	mcLogs := mock_service.NewMockawsLogsAPI(ctrl)
+	mcLogs.EXPECT().
+		DescribeLogGroups(gomock.Any(), &cloudwatchlogs.DescribeLogGroupsInput{}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []logstypes.LogGroup{
+				{
+					Arn:          aws.String("arn:aws:logs:ap-northeast-1:012345678901:log-group:/app:*"),
+					LogGroupName: aws.String("/app"),
+					CreationTime: aws.Int64(createdAt.UnixMilli()),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcLogs.EXPECT().
+		ListTagsForResource(gomock.Any(), &cloudwatchlogs.ListTagsForResourceInput{
+			ResourceArn: aws.String("arn:aws:logs:ap-northeast-1:012345678901:log-group:/app"),
+		}).
+		Return(&cloudwatchlogs.ListTagsForResourceOutput{}, nil).
+		AnyTimes()
+
+	mcRoute53 := mock_service.NewMockawsRoute53API(ctrl)
+	mcRoute53.EXPECT().
+		ListHostedZones(gomock.Any(), &route53.ListHostedZonesInput{}).
+		Return(&route53.ListHostedZonesOutput{
+			HostedZones: []route53types.HostedZone{
+				{
+					Id:   aws.String("/hostedzone/Z0123456789EXAMPLE"),
+					Name: aws.String("example.com."),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcRoute53.EXPECT().
+		ListTagsForResource(gomock.Any(), gomock.Any()).
+		Return(&route53.ListTagsForResourceOutput{}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		service  string
+		resource string
+		region   string
+		client   interface{}
+		expected Envelope
+	}{
+		{
+			name:     "wrap ec2 instance with generated arn",
+			service:  "ec2",
+			resource: "instance",
+			region:   "ap-northeast-1",
+			client:   mcEc2,
+			expected: Envelope{
+				ARN:       "arn:aws:ec2:ap-northeast-1:012345678901:instance/i-1234567890abcdef0",
+				ID:        "i-1234567890abcdef0",
+				Name:      "web",
+				Region:    "ap-northeast-1",
+				Account:   "012345678901",
+				Service:   "ec2",
+				Resource:  "instance",
+				Tags:      map[string]string{"Name": "web"},
+				CreatedAt: aws.Time(createdAt),
+			},
+		},
+		{
+			name:     "wrap ecs task-definition with tags of the describe output",
+			service:  "ecs",
+			resource: "task-definition",
+			region:   "ap-northeast-1",
+			client:   mcEcs,
+			expected: Envelope{
+				ARN:       "arn:aws:ecs:ap-northeast-1:012345678901:task-definition/testapp:1",
+				ID:        "arn:aws:ecs:ap-northeast-1:012345678901:task-definition/testapp:1",
+				Name:      "testapp:1",
+				Region:    "ap-northeast-1",
+				Account:   "012345678901",
+				Service:   "ecs",
+				Resource:  "task-definition",
+				Tags:      map[string]string{"env": "prod"},
+				CreatedAt: aws.Time(createdAt),
+			},
+		},
+		{
+			name:     "wrap iam role with listed tags",
+			service:  "iam",
+			resource: "role",
+			region:   "us-east-1",
+			client:   mcIam,
+			expected: Envelope{
+				ARN:       "arn:aws:iam::012345678901:role/admin",
+				ID:        "AROA0123456789EXAMPLE",
+				Name:      "admin",
+				Region:    "us-east-1",
+				Account:   "012345678901",
+				Service:   "iam",
+				Resource:  "role",
+				Tags:      map[string]string{"team": "infra"},
+				CreatedAt: aws.Time(createdAt),
+			},
+		},
+		{
+			name:     "wrap log group with arn usable in tagging apis",
+			service:  "logs",
+			resource: "log-group",
+			region:   "ap-northeast-1",
+			client:   mcLogs,
+			expected: Envelope{
+				ARN:       "arn:aws:logs:ap-northeast-1:012345678901:log-group:/app",
+				ID:        "/app",
+				Name:      "/app",
+				Region:    "ap-northeast-1",
+				Account:   "012345678901",
+				Service:   "logs",
+				Resource:  "log-group",
+				Tags:      map[string]string{},
+				CreatedAt: aws.Time(createdAt),
+			},
+		},
+		{
+			name:     "wrap route53 hosted zone with generated arn",
+			service:  "route53",
+			resource: "hosted-zone",
+			region:   "us-east-1",
+			client:   mcRoute53,
+			expected: Envelope{
+				ARN:      "arn:aws:route53:::hostedzone/Z0123456789EXAMPLE",
+				ID:       "Z0123456789EXAMPLE",
+				Name:     "example.com.",
+				Region:   "us-east-1",
+				Service:  "route53",
+				Resource: "hosted-zone",
+				Tags:     map[string]string{},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			opt := QueryOption{
+				Clients:  NewClientCache(),
+				Envelope: true,
+			}
+			opt.Clients.put("", tt.region, tt.service, tt.client)
+			def, _ := LookupService(tt.service)
+			api := def.New(config, []string{tt.region}, opt)
+
+			actual, err := api.Query(tt.resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual.Results) != 1 {
+				t.Fatalf("expected 1 result, but got %d", len(actual.Results))
+			}
+			envelope, ok := actual.Results[0].(Envelope)
+			if !ok {
+				t.Fatalf("expected Envelope, but got %T", actual.Results[0])
+			}
+			if envelope.Raw == nil {
+				t.Errorf("expected raw payload, but got nil")
+			}
+			envelope.Raw = nil
+			if !reflect.DeepEqual(envelope, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, envelope)
+			}
+		})
+	}
+}
+
+func TestAccountOfARN(t *testing.T) {
+	cases := []struct {
+		name     string
+		arn      string
+		expected string
+	}{
+		{
+			name:     "regional arn",
+			arn:      "arn:aws:ecs:ap-northeast-1:012345678901:cluster/default",
+			expected: "012345678901",
+		},
+		{
+			name:     "arn without account",
+			arn:      "arn:aws:s3:::example-bucket",
+			expected: "",
+		},
+		{
+			name:     "invalid arn",
+			arn:      "example-bucket",
+			expected: "",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := accountOfARN(tt.arn)
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
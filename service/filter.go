@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Filter selects resources by one of their attributes, such as the state of EC2 instances.
+// It is translated into the filter parameters of the list API of each resource.
+type Filter struct {
+	Name   string
+	Values []string
+}
+
+// Filters selects resources matching all of its filters.
+type Filters []Filter
+
+// filterDefinition describes a filter supported by a resource.
+type filterDefinition struct {
+	// name is a glob pattern of the filter names, such as "*" for the APIs taking any filter name.
+	name string
+	// single filters take a single value, as they are passed to a scalar parameter of the API.
+	single bool
+}
+
+// ParseFilter parses a filter in the syntax of the AWS CLI, "Name=name,Values=value1,value2".
+func ParseFilter(expr string) (Filter, error) {
+	var name, values string
+	var ok bool
+	switch {
+	case strings.HasPrefix(expr, "Name="):
+		name, values, ok = strings.Cut(strings.TrimPrefix(expr, "Name="), ",Values=")
+	case strings.HasPrefix(expr, "Values="):
+		values, name, ok = strings.Cut(strings.TrimPrefix(expr, "Values="), ",Name=")
+	}
+	if !ok || name == "" || values == "" {
+		return Filter{}, fmt.Errorf("invalid filter: '%s', expected Name=name,Values=value1,value2", expr)
+	}
+
+	return Filter{
+		Name:   name,
+		Values: strings.Split(values, ","),
+	}, nil
+}
+
+// singleValueFilters defines filters taking a single value.
+func singleValueFilters(names ...string) []filterDefinition {
+	filters := make([]filterDefinition, 0, len(names))
+	for _, name := range names {
+		filters = append(filters, filterDefinition{name: name, single: true})
+	}
+	return filters
+}
+
+// multiValueFilters defines filters taking one or more values.
+func multiValueFilters(names ...string) []filterDefinition {
+	filters := make([]filterDefinition, 0, len(names))
+	for _, name := range names {
+		filters = append(filters, filterDefinition{name: name})
+	}
+	return filters
+}
+
+// values returns the values of the filter named name, or nil when it is not given.
+func (f Filters) values(name string) []string {
+	var values []string
+	for _, filter := range f {
+		if filter.Name == name {
+			values = append(values, filter.Values...)
+		}
+	}
+	return values
+}
+
+// value returns the value of the single value filter named name, or an empty string when it is not given.
+func (f Filters) value(name string) string {
+	values := f.values(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// optionalString returns nil for an empty string, so that filters not given are not passed to the APIs.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// validateFilters checks if every filter is supported by the resource r of the service.
+func validateFilters(service string, r ResourceDefinition, filters Filters) error {
+	for _, filter := range filters {
+		def, ok := r.filterDefinition(filter.Name)
+		if !ok {
+			if len(r.filters) == 0 {
+				return fmt.Errorf("filter '%s' not supported: %s %s supports no filters", filter.Name, service, r.Name)
+			}
+			names := make([]string, 0, len(r.filters))
+			for _, f := range r.filters {
+				names = append(names, f.name)
+			}
+			return fmt.Errorf("filter '%s' not supported: %s %s supports %s", filter.Name, service, r.Name, strings.Join(names, ", "))
+		}
+		if def.single && len(filters.values(filter.Name)) > 1 {
+			return fmt.Errorf("filter '%s' of %s %s takes a single value", filter.Name, service, r.Name)
+		}
+	}
+	return nil
+}
+
+// filterDefinition returns the definition of the filter of the resource matching name.
+func (r ResourceDefinition) filterDefinition(name string) (filterDefinition, bool) {
+	for _, f := range r.filters {
+		if ok, _ := path.Match(f.name, name); ok {
+			return f, true
+		}
+	}
+	return filterDefinition{}, false
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		name      string
+		expr      string
+		expected  Filter
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "single value",
+			expr:     "Name=instance-state-name,Values=running",
+			expected: Filter{Name: "instance-state-name", Values: []string{"running"}},
+		},
+		{
+			name:     "multiple values",
+			expr:     "Name=instance-state-name,Values=running,stopped",
+			expected: Filter{Name: "instance-state-name", Values: []string{"running", "stopped"}},
+		},
+		{
+			name:     "values before name",
+			expr:     "Values=running,stopped,Name=instance-state-name",
+			expected: Filter{Name: "instance-state-name", Values: []string{"running", "stopped"}},
+		},
+		{
+			name:      "missing values",
+			expr:      "Name=instance-state-name",
+			wantErr:   true,
+			expectErr: "invalid filter: 'Name=instance-state-name'",
+		},
+		{
+			name:      "shorthand of other syntax",
+			expr:      "instance-state-name=running",
+			wantErr:   true,
+			expectErr: "invalid filter: 'instance-state-name=running'",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseFilter(tt.expr)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected nil, but got %v", err.Error())
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	cases := []struct {
+		name      string
+		service   string
+		resource  string
+		filters   Filters
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "any filter of ec2",
+			service:  "ec2",
+			resource: "instance",
+			filters:  Filters{{Name: "instance-state-name", Values: []string{"running", "stopped"}}},
+		},
+		{
+			name:     "native filter of ecs task",
+			service:  "ecs",
+			resource: "task",
+			filters:  Filters{{Name: "desired-status", Values: []string{"RUNNING"}}},
+		},
+		{
+			name:      "several values of single value filter",
+			service:   "ecs",
+			resource:  "task",
+			filters:   Filters{{Name: "desired-status", Values: []string{"RUNNING", "STOPPED"}}},
+			wantErr:   true,
+			expectErr: "filter 'desired-status' of ecs task takes a single value",
+		},
+		{
+			name:      "unsupported filter",
+			service:   "lambda",
+			resource:  "function",
+			filters:   Filters{{Name: "runtime", Values: []string{"go1.x"}}},
+			wantErr:   true,
+			expectErr: "filter 'runtime' not supported: lambda function supports function-version, master-region",
+		},
+		{
+			name:      "resource without filters",
+			service:   "ecr",
+			resource:  "repository",
+			filters:   Filters{{Name: "repository-name", Values: []string{"app"}}},
+			wantErr:   true,
+			expectErr: "filter 'repository-name' not supported: ecr repository supports no filters",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			def, _ := LookupService(tt.service)
+			err := def.ValidateFilters(tt.resource, tt.filters)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected nil, but got %v", err.Error())
+			}
+		})
+	}
+}
+
+func TestQueryWithFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mcEc2 := mock_service.NewMockawsEc2API(ctrl)
+	mcEc2.EXPECT().
+		DescribeInstances(gomock.Any(), &ec2.DescribeInstancesInput{
+			Filters: []ec2types.Filter{
+				{Name: aws.String("instance-state-name"), Values: []string{"running", "stopped"}},
+			},
+		}).
+		Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{Instances: []ec2types.Instance{{InstanceId: aws.String("i-1234567890abcdef0")}}},
+			},
+		}, nil).
+		Times(1)
+
+	mcEcs := mock_service.NewMockawsEcsAPI(ctrl)
+	mcEcs.EXPECT().
+		ListClusters(gomock.Any(), gomock.Any()).
+		Return(&ecs.ListClustersOutput{
+			ClusterArns: []string{"arn:aws:ecs:ap-northeast-1:012345678901:cluster/production"},
+		}, nil).
+		Times(1)
+	mcEcs.EXPECT().
+		ListTasks(gomock.Any(), &ecs.ListTasksInput{
+			Cluster:       aws.String("arn:aws:ecs:ap-northeast-1:012345678901:cluster/production"),
+			DesiredStatus: ecstypes.DesiredStatusStopped,
+			LaunchType:    ecstypes.LaunchTypeFargate,
+		}).
+		Return(&ecs.ListTasksOutput{
+			TaskArns: []string{"arn:aws:ecs:ap-northeast-1:012345678901:task/production/0123456789abcdef"},
+		}, nil).
+		Times(1)
+	mcEcs.EXPECT().
+		DescribeTasks(gomock.Any(), gomock.Any()).
+		Return(&ecs.DescribeTasksOutput{
+			Tasks: []ecstypes.Task{
+				{TaskArn: aws.String("arn:aws:ecs:ap-northeast-1:012345678901:task/production/0123456789abcdef")},
+			},
+		}, nil).
+		Times(1)
+
+	mcLambda := mock_service.NewMockawsLambdaAPI(ctrl)
+	mcLambda.EXPECT().
+		ListFunctions(gomock.Any(), &lambda.ListFunctionsInput{
+			FunctionVersion: lambdatypes.FunctionVersionAll,
+		}).
+		Return(&lambda.ListFunctionsOutput{
+			Functions: []lambdatypes.FunctionConfiguration{{FunctionName: aws.String("handler")}},
+		}, nil).
+		Times(1)
+
+	mcCfn := mock_service.NewMockawsCloudformationAPI(ctrl)
+	mcCfn.EXPECT().
+		ListStacks(gomock.Any(), &cloudformation.ListStacksInput{
+			StackStatusFilter: []cfntypes.StackStatus{cfntypes.StackStatusDeleteComplete},
+		}).
+		Return(&cloudformation.ListStacksOutput{
+			StackSummaries: []cfntypes.StackSummary{{StackId: aws.String("arn:aws:cloudformation:ap-northeast-1:012345678901:stack/app/0123")}},
+		}, nil).
+		Times(1)
+	mcCfn.EXPECT().
+		DescribeStacks(gomock.Any(), &cloudformation.DescribeStacksInput{
+			StackName: aws.String("arn:aws:cloudformation:ap-northeast-1:012345678901:stack/app/0123"),
+		}).
+		Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []cfntypes.Stack{{StackName: aws.String("app"), StackStatus: cfntypes.StackStatusDeleteComplete}},
+		}, nil).
+		Times(1)
+
+	cases := []struct {
+		name     string
+		service  string
+		resource string
+		filters  Filters
+		client   interface{}
+	}{
+		{
+			name:     "pass filters to ec2 describe api",
+			service:  "ec2",
+			resource: "instance",
+			filters:  Filters{{Name: "instance-state-name", Values: []string{"running", "stopped"}}},
+			client:   mcEc2,
+		},
+		{
+			name:     "translate filters into parameters of ecs list tasks",
+			service:  "ecs",
+			resource: "task",
+			filters: Filters{
+				{Name: "desired-status", Values: []string{"STOPPED"}},
+				{Name: "launch-type", Values: []string{"FARGATE"}},
+			},
+			client: mcEcs,
+		},
+		{
+			name:     "translate filter into function version of lambda",
+			service:  "lambda",
+			resource: "function",
+			filters:  Filters{{Name: "function-version", Values: []string{"ALL"}}},
+			client:   mcLambda,
+		},
+		{
+			name:     "list cloudformation stacks by status",
+			service:  "cloudformation",
+			resource: "stack",
+			filters:  Filters{{Name: "stack-status", Values: []string{"DELETE_COMPLETE"}}},
+			client:   mcCfn,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			opt := QueryOption{
+				Clients: NewClientCache(),
+				Filters: tt.filters,
+			}
+			opt.Clients.put("", "ap-northeast-1", tt.service, tt.client)
+			def, _ := LookupService(tt.service)
+			api := def.New(config, []string{"ap-northeast-1"}, opt)
+
+			actual, err := api.Query(tt.resource)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual.Results) != 1 {
+				t.Errorf("expected 1 result, but got %d", len(actual.Results))
+			}
+		})
+	}
+}
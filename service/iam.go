@@ -3,14 +3,10 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
 )
 
 type awsIamAPI interface {
@@ -19,198 +15,267 @@ type awsIamAPI interface {
 	ListPolicies(ctx context.Context, params *iam.ListPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListPoliciesOutput, error)
 	ListRoles(ctx context.Context, params *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error)
 	ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error)
+	ListPolicyTags(ctx context.Context, params *iam.ListPolicyTagsInput, optFns ...func(*iam.Options)) (*iam.ListPolicyTagsOutput, error)
+	ListRoleTags(ctx context.Context, params *iam.ListRoleTagsInput, optFns ...func(*iam.Options)) (*iam.ListRoleTagsOutput, error)
+	ListUserTags(ctx context.Context, params *iam.ListUserTagsInput, optFns ...func(*iam.Options)) (*iam.ListUserTagsOutput, error)
+	GetGroup(ctx context.Context, params *iam.GetGroupInput, optFns ...func(*iam.Options)) (*iam.GetGroupOutput, error)
+	GetPolicy(ctx context.Context, params *iam.GetPolicyInput, optFns ...func(*iam.Options)) (*iam.GetPolicyOutput, error)
+	GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error)
+	GetUser(ctx context.Context, params *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error)
 }
 
+var iamService = register(ServiceDefinition{
+	Name:        "iam",
+	Description: "AWS Identity and Access Management",
+	Global:      true,
+	Resources: []ResourceDefinition{
+		newResource("access-key", "access keys of the calling IAM user", []string{"UserName", "AccessKeyId", "Status", "CreateDate"},
+			func(api *AwsresqIamAPI) ResourceQueryAPI { return api.queryIamAccessKey }),
+		newResource("group", "IAM groups", []string{"GroupName", "GroupId", "CreateDate"},
+			func(api *AwsresqIamAPI) ResourceQueryAPI { return api.queryIamGroup }).withARN("group"),
+		newResource("policy", "IAM policies", []string{"PolicyName", "AttachmentCount", "DefaultVersionId", "UpdateDate"},
+			func(api *AwsresqIamAPI) ResourceQueryAPI { return api.queryIamPolicy }).withARN("policy"),
+		newResource("role", "IAM roles", []string{"RoleName", "RoleId", "CreateDate"},
+			func(api *AwsresqIamAPI) ResourceQueryAPI { return api.queryIamRole }).withARN("role"),
+		newResource("user", "IAM users", []string{"UserName", "UserId", "CreateDate"},
+			func(api *AwsresqIamAPI) ResourceQueryAPI { return api.queryIamUser }).withARN("user"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqIamAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqIamAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsIamAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqIamAPI(c aws.Config, region []string) *AwsresqIamAPI {
+func NewAwsresqIamAPI(c aws.Config, region []string, opt QueryOption) *AwsresqIamAPI {
 	return &AwsresqIamAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsIamAPI, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqIamAPI) Validate(resource string) bool {
-	validResource := []string{
-		"access-key",
-		"group",
-		"policy",
-		"role",
-		"user",
-	}
-	return slices.Contains(validResource, resource)
+func (api *AwsresqIamAPI) Validate(resource string) bool {
+	return iamService.Validate(resource)
 }
 
-func (api AwsresqIamAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "iam",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	api.region = []string{"us-east-1"}
-	switch resource {
-	case "access-key":
-		apiQuery = api.queryIamAccessKey
-	case "group":
-		apiQuery = api.queryIamGroup
-	case "policy":
-		apiQuery = api.queryIamPolicy
-	case "role":
-		apiQuery = api.queryIamRole
-	case "user":
-		apiQuery = api.queryIamUser
-	default:
-		return nil, fmt.Errorf("resource %s is not supported in iam service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, region := range api.region {
-		go apiQuery(ctx, ch, region)
-	}
-
-	for range api.region {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		}
-	}
-
-	return resultList, nil
+func (api *AwsresqIamAPI) DefaultColumns(resource string) []string {
+	return iamService.DefaultColumns(resource)
 }
 
-func (api AwsresqIamAPI) queryIamAccessKey(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "iam",
-		Resource: "access-key",
-	}
-
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
-			o.Region = region
-		})
-	}
-
-	listOutput, err := api.apiClient[region].ListAccessKeys(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list access-keys in region %s", region)
-		return
-	}
-	for _, group := range listOutput.AccessKeyMetadata {
-		resultList.Results = append(resultList.Results, group)
-	}
-
-	ch <- resultList
+func (api *AwsresqIamAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api AwsresqIamAPI) queryIamGroup(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "iam",
-		Resource: "group",
-	}
-
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
-			o.Region = region
-		})
-	}
-
-	listOutput, err := api.apiClient[region].ListGroups(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list groups in region %s", region)
-		return
-	}
-	for _, group := range listOutput.Groups {
-		resultList.Results = append(resultList.Results, group)
-	}
-
-	ch <- resultList
+func (api *AwsresqIamAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return iamService.query(ctx, api, resource, api.region, api.opt)
 }
 
-func (api AwsresqIamAPI) queryIamPolicy(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "iam",
-		Resource: "policy",
-	}
-
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
+func (api *AwsresqIamAPI) client(region string) awsIamAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "iam", func() awsIamAPI {
+		return iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
 			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[region].ListPolicies(ctx, &iam.ListPoliciesInput{
-		// ignore AWS managed policies
-		Scope: types.PolicyScopeTypeLocal,
 	})
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list policies in region %s", region)
-		return
-	}
-	for _, policy := range listOutput.Policies {
-		resultList.Results = append(resultList.Results, policy)
-	}
+}
 
-	ch <- resultList
+func (api *AwsresqIamAPI) queryIamAccessKey(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsIamAPI, *iam.ListAccessKeysOutput, types.AccessKeyMetadata, types.AccessKeyMetadata]{
+		service:  "iam",
+		resource: "access-key",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsIamAPI, _ string, token *string) (*iam.ListAccessKeysOutput, error) {
+			return client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{Marker: token})
+		},
+		items: func(output *iam.ListAccessKeysOutput) ([]types.AccessKeyMetadata, *string) {
+			return output.AccessKeyMetadata, output.Marker
+		},
+		// access keys cannot be tagged
+		tags: untagged[awsIamAPI, types.AccessKeyMetadata],
+		// access keys have no ARN
+		envelope: func(key types.AccessKeyMetadata) Envelope {
+			return Envelope{
+				ID:        aws.ToString(key.AccessKeyId),
+				Name:      aws.ToString(key.UserName),
+				CreatedAt: key.CreateDate,
+			}
+		},
+	}.run(ctx, ch, region)
 }
 
-func (api AwsresqIamAPI) queryIamRole(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "iam",
-		Resource: "role",
-	}
+func (api *AwsresqIamAPI) queryIamGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsIamAPI, *iam.ListGroupsOutput, types.Group, types.Group]{
+		service:  "iam",
+		resource: "group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsIamAPI, _ string, token *string) (*iam.ListGroupsOutput, error) {
+			return client.ListGroups(ctx, &iam.ListGroupsInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsIamAPI, target ResourceARN) (string, *iam.ListGroupsOutput, error) {
+			output, err := client.GetGroup(ctx, &iam.GetGroupInput{
+				GroupName: aws.String(lastSegment(target.ID)),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &iam.ListGroupsOutput{Groups: []types.Group{*output.Group}}, nil
+		},
+		items: func(output *iam.ListGroupsOutput) ([]types.Group, *string) {
+			return output.Groups, output.Marker
+		},
+		// groups cannot be tagged
+		tags: untagged[awsIamAPI, types.Group],
+		envelope: func(group types.Group) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(group.Arn),
+				ID:        aws.ToString(group.GroupId),
+				Name:      aws.ToString(group.GroupName),
+				CreatedAt: group.CreateDate,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
-			o.Region = region
-		})
-	}
+func (api *AwsresqIamAPI) queryIamPolicy(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsIamAPI, *iam.ListPoliciesOutput, types.Policy, types.Policy]{
+		service:  "iam",
+		resource: "policy",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsIamAPI, _ string, token *string) (*iam.ListPoliciesOutput, error) {
+			return client.ListPolicies(ctx, &iam.ListPoliciesInput{
+				Marker: token,
+				// ignore AWS managed policies
+				Scope: types.PolicyScopeTypeLocal,
+			})
+		},
+		lookup: func(ctx context.Context, client awsIamAPI, target ResourceARN) (string, *iam.ListPoliciesOutput, error) {
+			output, err := client.GetPolicy(ctx, &iam.GetPolicyInput{
+				PolicyArn: aws.String(target.String()),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &iam.ListPoliciesOutput{Policies: []types.Policy{*output.Policy}}, nil
+		},
+		items: func(output *iam.ListPoliciesOutput) ([]types.Policy, *string) {
+			return output.Policies, output.Marker
+		},
+		tags: func(ctx context.Context, client awsIamAPI, policy types.Policy) (map[string]string, error) {
+			output, err := client.ListPolicyTags(ctx, &iam.ListPolicyTagsInput{
+				PolicyArn: policy.Arn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return iamTagMap(output.Tags), nil
+		},
+		envelope: func(policy types.Policy) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(policy.Arn),
+				ID:        aws.ToString(policy.PolicyId),
+				Name:      aws.ToString(policy.PolicyName),
+				CreatedAt: policy.CreateDate,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	listOutput, err := api.apiClient[region].ListRoles(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list roles in region %s", region)
-		return
-	}
-	for _, role := range listOutput.Roles {
+func (api *AwsresqIamAPI) queryIamRole(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsIamAPI, *iam.ListRolesOutput, types.Role, types.Role]{
+		service:  "iam",
+		resource: "role",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsIamAPI, _ string, token *string) (*iam.ListRolesOutput, error) {
+			return client.ListRoles(ctx, &iam.ListRolesInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsIamAPI, target ResourceARN) (string, *iam.ListRolesOutput, error) {
+			output, err := client.GetRole(ctx, &iam.GetRoleInput{
+				RoleName: aws.String(lastSegment(target.ID)),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &iam.ListRolesOutput{Roles: []types.Role{*output.Role}}, nil
+		},
 		// AssumeRolePolicyDocument is URL encoded. It needs to be unescaped as below for query result.
 		// doc, _ := url.PathUnescape(*role.AssumeRolePolicyDocument)
 		// role.AssumeRolePolicyDocument = aws.String(doc)
-		resultList.Results = append(resultList.Results, role)
-	}
-
-	ch <- resultList
+		items: func(output *iam.ListRolesOutput) ([]types.Role, *string) {
+			return output.Roles, output.Marker
+		},
+		// tags are not included in the result of ListRoles
+		tags: func(ctx context.Context, client awsIamAPI, role types.Role) (map[string]string, error) {
+			output, err := client.ListRoleTags(ctx, &iam.ListRoleTagsInput{
+				RoleName: role.RoleName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return iamTagMap(output.Tags), nil
+		},
+		envelope: func(role types.Role) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(role.Arn),
+				ID:        aws.ToString(role.RoleId),
+				Name:      aws.ToString(role.RoleName),
+				CreatedAt: role.CreateDate,
+			}
+		},
+	}.run(ctx, ch, region)
 }
 
-func (api AwsresqIamAPI) queryIamUser(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "iam",
-		Resource: "user",
-	}
-
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = iam.NewFromConfig(api.awsCfg, func(o *iam.Options) {
-			o.Region = region
-		})
-	}
-
-	listOutput, err := api.apiClient[region].ListUsers(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("failed to list users in region %s", region)
-		return
-	}
-	for _, user := range listOutput.Users {
-		resultList.Results = append(resultList.Results, user)
-	}
+func (api *AwsresqIamAPI) queryIamUser(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsIamAPI, *iam.ListUsersOutput, types.User, types.User]{
+		service:  "iam",
+		resource: "user",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsIamAPI, _ string, token *string) (*iam.ListUsersOutput, error) {
+			return client.ListUsers(ctx, &iam.ListUsersInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsIamAPI, target ResourceARN) (string, *iam.ListUsersOutput, error) {
+			output, err := client.GetUser(ctx, &iam.GetUserInput{
+				UserName: aws.String(lastSegment(target.ID)),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &iam.ListUsersOutput{Users: []types.User{*output.User}}, nil
+		},
+		items: func(output *iam.ListUsersOutput) ([]types.User, *string) {
+			return output.Users, output.Marker
+		},
+		// tags are not included in the result of ListUsers
+		tags: func(ctx context.Context, client awsIamAPI, user types.User) (map[string]string, error) {
+			output, err := client.ListUserTags(ctx, &iam.ListUserTagsInput{
+				UserName: user.UserName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return iamTagMap(output.Tags), nil
+		},
+		envelope: func(user types.User) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(user.Arn),
+				ID:        aws.ToString(user.UserId),
+				Name:      aws.ToString(user.UserName),
+				CreatedAt: user.CreateDate,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func iamTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
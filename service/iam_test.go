@@ -69,7 +69,7 @@ func TestIamAccessKeysQuery(t *testing.T) {
 	mc := mock_service.NewMockawsIamAPI(ctrl)
 
 	mc.EXPECT().
-		ListAccessKeys(gomock.Any(), nil).
+		ListAccessKeys(gomock.Any(), &iam.ListAccessKeysInput{}).
 		Return(&iam.ListAccessKeysOutput{
 			AccessKeyMetadata: []types.AccessKeyMetadata{
 				{
@@ -101,8 +101,8 @@ func TestIamAccessKeysQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqIamAPI(config, []string{"us-east-1"})
-			api.apiClient["us-east-1"] = mc
+			api := NewAwsresqIamAPI(config, []string{"us-east-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "iam", mc)
 
 			actual, err := api.Query("access-key")
 
@@ -150,7 +150,7 @@ func TestIamGroupQuery(t *testing.T) {
 	mc := mock_service.NewMockawsIamAPI(ctrl)
 
 	mc.EXPECT().
-		ListGroups(gomock.Any(), nil).
+		ListGroups(gomock.Any(), &iam.ListGroupsInput{}).
 		Return(&iam.ListGroupsOutput{
 			Groups: []types.Group{
 				{
@@ -180,8 +180,8 @@ func TestIamGroupQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqIamAPI(config, []string{"us-east-1"})
-			api.apiClient["us-east-1"] = mc
+			api := NewAwsresqIamAPI(config, []string{"us-east-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "iam", mc)
 
 			actual, err := api.Query("group")
 
@@ -258,8 +258,8 @@ func TestIamPolicyQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqIamAPI(config, []string{"us-east-1"})
-			api.apiClient["us-east-1"] = mc
+			api := NewAwsresqIamAPI(config, []string{"us-east-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "iam", mc)
 
 			actual, err := api.Query("policy")
 
@@ -304,7 +304,7 @@ func TestIamRoleQuery(t *testing.T) {
 	mc := mock_service.NewMockawsIamAPI(ctrl)
 
 	mc.EXPECT().
-		ListRoles(gomock.Any(), nil).
+		ListRoles(gomock.Any(), &iam.ListRolesInput{}).
 		Return(&iam.ListRolesOutput{
 			Roles: []types.Role{
 				{
@@ -334,8 +334,8 @@ func TestIamRoleQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqIamAPI(config, []string{"us-east-1"})
-			api.apiClient["us-east-1"] = mc
+			api := NewAwsresqIamAPI(config, []string{"us-east-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "iam", mc)
 
 			actual, err := api.Query("role")
 
@@ -380,13 +380,26 @@ func TestIamUserQuery(t *testing.T) {
 	mc := mock_service.NewMockawsIamAPI(ctrl)
 
 	mc.EXPECT().
-		ListUsers(gomock.Any(), nil).
+		ListUsers(gomock.Any(), &iam.ListUsersInput{}).
 		Return(&iam.ListUsersOutput{
 			Users: []types.User{
 				{
 					UserName: aws.String("test-user"),
 				},
 			},
+			Marker: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListUsers(gomock.Any(), &iam.ListUsersInput{
+			Marker: aws.String("next-token"),
+		}).
+		Return(&iam.ListUsersOutput{
+			Users: []types.User{
+				{
+					UserName: aws.String("another-user"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -402,6 +415,9 @@ func TestIamUserQuery(t *testing.T) {
 				{
 					UserName: aws.String("test-user"),
 				},
+				{
+					UserName: aws.String("another-user"),
+				},
 			},
 			wantErr: false,
 		},
@@ -410,8 +426,8 @@ func TestIamUserQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqIamAPI(config, []string{"us-east-1"})
-			api.apiClient["us-east-1"] = mc
+			api := NewAwsresqIamAPI(config, []string{"us-east-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "iam", mc)
 
 			actual, err := api.Query("user")
 
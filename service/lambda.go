@@ -3,97 +3,112 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
 type awsLambdaAPI interface {
 	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+	ListTags(ctx context.Context, params *lambda.ListTagsInput, optFns ...func(*lambda.Options)) (*lambda.ListTagsOutput, error)
+	GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error)
 }
 
+var lambdaService = register(ServiceDefinition{
+	Name:        "lambda",
+	Description: "AWS Lambda",
+	Resources: []ResourceDefinition{
+		newResource("function", "Lambda functions", []string{"FunctionName", "Runtime", "MemorySize", "LastModified"},
+			func(api *AwsresqLambdaAPI) ResourceQueryAPI { return api.queryFunction }).
+			withARN("function").
+			withFilters(singleValueFilters("function-version", "master-region")...),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqLambdaAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqLambdaAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsLambdaAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqLambdaAPI(c aws.Config, region []string) *AwsresqLambdaAPI {
+func NewAwsresqLambdaAPI(c aws.Config, region []string, opt QueryOption) *AwsresqLambdaAPI {
 	return &AwsresqLambdaAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsLambdaAPI, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqLambdaAPI) Validate(resource string) bool {
-	validResources := []string{
-		"function",
-	}
-
-	return slices.Contains(validResources, resource)
+func (api *AwsresqLambdaAPI) Validate(resource string) bool {
+	return lambdaService.Validate(resource)
 }
 
-func (api AwsresqLambdaAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "lambda",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "function":
-		apiQuery = api.queryFunction
-	default:
-		return nil, fmt.Errorf("resource '%s' not supported in lambda service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			if result.Results != nil {
-				resultList.Results = append(resultList.Results, result.Results...)
-			}
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqLambdaAPI) DefaultColumns(resource string) []string {
+	return lambdaService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqLambdaAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqLambdaAPI) queryFunction(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "lambda",
-		Resource: "function",
-	}
+func (api *AwsresqLambdaAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return lambdaService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = lambda.NewFromConfig(api.awsCfg, func(o *lambda.Options) {
-			o.Region = r
+func (api *AwsresqLambdaAPI) client(region string) awsLambdaAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "lambda", func() awsLambdaAPI {
+		return lambda.NewFromConfig(api.awsCfg, func(o *lambda.Options) {
+			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[r].ListFunctions(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("failed to list functions in %s: %s", r, err.Error())
-		return
-	}
-	for _, function := range listOutput.Functions {
-		resultList.Results = append(resultList.Results, function)
-	}
+	})
+}
 
-	ch <- resultList
+func (api *AwsresqLambdaAPI) queryFunction(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsLambdaAPI, *lambda.ListFunctionsOutput, types.FunctionConfiguration, types.FunctionConfiguration]{
+		service:  "lambda",
+		resource: "function",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsLambdaAPI, _ string, token *string) (*lambda.ListFunctionsOutput, error) {
+			return client.ListFunctions(ctx, &lambda.ListFunctionsInput{
+				FunctionVersion: types.FunctionVersion(api.opt.Filters.value("function-version")),
+				MasterRegion:    optionalString(api.opt.Filters.value("master-region")),
+				Marker:          token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsLambdaAPI, target ResourceARN) (string, *lambda.ListFunctionsOutput, error) {
+			output, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+				FunctionName: aws.String(target.String()),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &lambda.ListFunctionsOutput{Functions: []types.FunctionConfiguration{*output.Configuration}}, nil
+		},
+		items: func(output *lambda.ListFunctionsOutput) ([]types.FunctionConfiguration, *string) {
+			return output.Functions, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsLambdaAPI, function types.FunctionConfiguration) (map[string]string, error) {
+			output, err := client.ListTags(ctx, &lambda.ListTagsInput{
+				Resource: function.FunctionArn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.Tags, nil
+		},
+		envelope: func(function types.FunctionConfiguration) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(function.FunctionArn),
+				ID:   aws.ToString(function.FunctionName),
+				Name: aws.ToString(function.FunctionName),
+			}
+		},
+	}.run(ctx, ch, region)
 }
@@ -50,13 +50,26 @@ func TestLambdaQuery(t *testing.T) {
 	mc := mock_service.NewMockawsLambdaAPI(ctrl)
 
 	mc.EXPECT().
-		ListFunctions(gomock.Any(), nil).
+		ListFunctions(gomock.Any(), &lambda.ListFunctionsInput{}).
 		Return(&lambda.ListFunctionsOutput{
 			Functions: []types.FunctionConfiguration{
 				{
 					FunctionName: aws.String("testapp"),
 				},
 			},
+			NextMarker: aws.String("next-marker"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListFunctions(gomock.Any(), &lambda.ListFunctionsInput{
+			Marker: aws.String("next-marker"),
+		}).
+		Return(&lambda.ListFunctionsOutput{
+			Functions: []types.FunctionConfiguration{
+				{
+					FunctionName: aws.String("sampleapp"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -67,12 +80,15 @@ func TestLambdaQuery(t *testing.T) {
 		expectErr string
 	}{
 		{
-			name: "query function resource",
+			name: "query function resource across pages",
 			expected: &lambda.ListFunctionsOutput{
 				Functions: []types.FunctionConfiguration{
 					{
 						FunctionName: aws.String("testapp"),
 					},
+					{
+						FunctionName: aws.String("sampleapp"),
+					},
 				},
 			},
 			wantErr: false,
@@ -82,8 +98,8 @@ func TestLambdaQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqLambdaAPI(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqLambdaAPI(config, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "lambda", mc)
 
 			actual, err := api.Query("function")
 
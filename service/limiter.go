@@ -0,0 +1,39 @@
+package service
+
+import "context"
+
+// Limiter bounds the number of regions queried at the same time by every query sharing it,
+// so that querying several resources or accounts at once does not multiply the bound.
+// A nil Limiter does not bound anything.
+type Limiter struct {
+	sem chan struct{}
+}
+
+// NewLimiter returns a limiter of n concurrent region queries, or nil for zero or less, which means no limit.
+func NewLimiter(n int) *Limiter {
+	if n <= 0 {
+		return nil
+	}
+	return &Limiter{sem: make(chan struct{}, n)}
+}
+
+// acquire waits until a region can be queried or ctx is done.
+func (l *Limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release ends a region query started with acquire.
+func (l *Limiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.sem
+}
@@ -3,98 +3,116 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 )
 
 type awsLogsAPI interface {
 	DescribeLogGroups(ctx context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	ListTagsForResource(ctx context.Context, params *cloudwatchlogs.ListTagsForResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error)
 }
 
+var logsService = register(ServiceDefinition{
+	Name:        "logs",
+	Aliases:     []string{"cloudwatchlogs"},
+	Description: "Amazon CloudWatch Logs",
+	Resources: []ResourceDefinition{
+		newResource("log-group", "CloudWatch Logs log groups", []string{"LogGroupName", "RetentionInDays", "StoredBytes"},
+			func(api *AwsresqLogsAPI) ResourceQueryAPI { return api.queryLogGroup }).withARN("log-group"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqLogsAPI(cfg, region, opt)
+	},
+})
+
 type AwsresqLogsAPI struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsLogsAPI
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqLogsAPI(c aws.Config, region []string) *AwsresqLogsAPI {
+func NewAwsresqLogsAPI(c aws.Config, region []string, opt QueryOption) *AwsresqLogsAPI {
 	return &AwsresqLogsAPI{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsLogsAPI, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqLogsAPI) Validate(resource string) bool {
-	validResoruces := []string{
-		"log-group",
-	}
-
-	return slices.Contains(validResoruces, resource)
+func (api *AwsresqLogsAPI) Validate(resource string) bool {
+	return logsService.Validate(resource)
 }
 
-func (api AwsresqLogsAPI) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "logs",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "log-group":
-		apiQuery = api.queryLogGroup
-	default:
-		return nil, fmt.Errorf("resource %s not supported in logs service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			if result.Results != nil {
-				resultList.Results = append(resultList.Results, result.Results...)
-			}
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqLogsAPI) DefaultColumns(resource string) []string {
+	return logsService.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqLogsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api *AwsresqLogsAPI) queryLogGroup(ctx context.Context, ch chan ResultList, r string) {
-	resultList := ResultList{
-		Service:  "logs",
-		Resource: "log-group",
-	}
+func (api *AwsresqLogsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return logsService.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[r] == nil {
-		api.apiClient[r] = cloudwatchlogs.NewFromConfig(api.awsCfg, func(o *cloudwatchlogs.Options) {
-			o.Region = r
+func (api *AwsresqLogsAPI) client(region string) awsLogsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "logs", func() awsLogsAPI {
+		return cloudwatchlogs.NewFromConfig(api.awsCfg, func(o *cloudwatchlogs.Options) {
+			o.Region = region
 		})
-	}
-
-	listOutput, err := api.apiClient[r].DescribeLogGroups(ctx, nil)
-	if err != nil {
-		log.Error().Msgf("error querying log groups in region %s: %s", r, err)
-		return
-	}
-
-	for _, lg := range listOutput.LogGroups {
-		resultList.Results = append(resultList.Results, lg)
-	}
+	})
+}
 
-	ch <- resultList
+func (api *AwsresqLogsAPI) queryLogGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsLogsAPI, *cloudwatchlogs.DescribeLogGroupsOutput, types.LogGroup, types.LogGroup]{
+		service:  "logs",
+		resource: "log-group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsLogsAPI, _ string, token *string) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+			return client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{NextToken: token})
+		},
+		// log groups can only be described by prefix, whose first page starts with the log group of the name
+		lookup: func(ctx context.Context, client awsLogsAPI, target ResourceARN) (string, *cloudwatchlogs.DescribeLogGroupsOutput, error) {
+			output, err := client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+				LogGroupNamePrefix: aws.String(target.ID),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			var logGroups []types.LogGroup
+			for _, lg := range output.LogGroups {
+				if aws.ToString(lg.LogGroupName) == target.ID {
+					logGroups = append(logGroups, lg)
+				}
+			}
+			return "", &cloudwatchlogs.DescribeLogGroupsOutput{LogGroups: logGroups}, nil
+		},
+		items: func(output *cloudwatchlogs.DescribeLogGroupsOutput) ([]types.LogGroup, *string) {
+			return output.LogGroups, output.NextToken
+		},
+		tags: func(ctx context.Context, client awsLogsAPI, lg types.LogGroup) (map[string]string, error) {
+			// the ARN of a log group returned by DescribeLogGroups ends with ":*", which tagging APIs do not accept
+			output, err := client.ListTagsForResource(ctx, &cloudwatchlogs.ListTagsForResourceInput{
+				ResourceArn: aws.String(strings.TrimSuffix(aws.ToString(lg.Arn), ":*")),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return output.Tags, nil
+		},
+		envelope: func(lg types.LogGroup) Envelope {
+			return Envelope{
+				ARN:       strings.TrimSuffix(aws.ToString(lg.Arn), ":*"),
+				ID:        aws.ToString(lg.LogGroupName),
+				Name:      aws.ToString(lg.LogGroupName),
+				CreatedAt: unixMilli(lg.CreationTime),
+			}
+		},
+	}.run(ctx, ch, region)
 }
@@ -44,13 +44,26 @@ func TestLogsQuery(t *testing.T) {
 	mc := mock_service.NewMockawsLogsAPI(ctrl)
 
 	mc.EXPECT().
-		DescribeLogGroups(gomock.Any(), nil).
+		DescribeLogGroups(gomock.Any(), &cloudwatchlogs.DescribeLogGroupsInput{}).
 		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
 			LogGroups: []types.LogGroup{
 				{
 					LogGroupName: aws.String("/aws/lambda/test-lambda01"),
 				},
 			},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeLogGroups(gomock.Any(), &cloudwatchlogs.DescribeLogGroupsInput{
+			NextToken: aws.String("next-token"),
+		}).
+		Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []types.LogGroup{
+				{
+					LogGroupName: aws.String("/aws/lambda/test-lambda02"),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -69,6 +82,9 @@ func TestLogsQuery(t *testing.T) {
 					{
 						LogGroupName: aws.String("/aws/lambda/test-lambda01"),
 					},
+					{
+						LogGroupName: aws.String("/aws/lambda/test-lambda02"),
+					},
 				},
 			},
 		},
@@ -80,8 +96,8 @@ func TestLogsQuery(t *testing.T) {
 			if err != nil {
 				t.Errorf("failed to load config: %v", err)
 			}
-			api := NewAwsresqLogsAPI(cfg, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqLogsAPI(cfg, []string{"ap-northeast-1"}, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "logs", mc)
 
 			actual, err := api.Query(tt.resource)
 
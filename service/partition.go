@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Partition is a group of AWS regions sharing the same endpoints and ARN namespace.
+type Partition struct {
+	ID string
+	// DefaultRegion is used for API calls when no region is configured.
+	DefaultRegion string
+	// GlobalRegions are the regions of the services having a single endpoint in the partition.
+	// Services not listed use DefaultRegion.
+	GlobalRegions map[string]string
+	// Regions are enabled in every account.
+	Regions []string
+	// OptInRegions must be enabled in the account before use. When the regions enabled in an account
+	// cannot be described, they are only searched by region patterns matching no region in Regions.
+	OptInRegions []string
+}
+
+// partitions lists the supported partitions.
+// Their regions are used when the regions enabled in an account cannot be described.
+var partitions = []Partition{
+	{
+		ID:            "aws",
+		DefaultRegion: "us-east-1",
+		Regions: []string{
+			"ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-south-1", "ap-southeast-1", "ap-southeast-2",
+			"ca-central-1",
+			"eu-central-1", "eu-north-1", "eu-west-1", "eu-west-2", "eu-west-3",
+			"sa-east-1",
+			"us-east-1", "us-east-2", "us-west-1", "us-west-2",
+		},
+		OptInRegions: []string{
+			"af-south-1",
+			"ap-east-1", "ap-east-2", "ap-south-2", "ap-southeast-3", "ap-southeast-4", "ap-southeast-5", "ap-southeast-7",
+			"ca-west-1",
+			"eu-central-2", "eu-south-1", "eu-south-2",
+			"il-central-1",
+			"me-central-1", "me-south-1",
+			"mx-central-1",
+		},
+	},
+	{
+		ID:            "aws-us-gov",
+		DefaultRegion: "us-gov-west-1",
+		Regions:       []string{"us-gov-east-1", "us-gov-west-1"},
+	},
+	{
+		ID:            "aws-cn",
+		DefaultRegion: "cn-north-1",
+		GlobalRegions: map[string]string{
+			"organizations": "cn-northwest-1",
+			"route53":       "cn-northwest-1",
+		},
+		Regions: []string{"cn-north-1", "cn-northwest-1"},
+	},
+}
+
+// PartitionOfRegion returns the partition region belongs to.
+// Unknown regions are assumed to be in the aws partition.
+func PartitionOfRegion(region string) Partition {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return partitions[1]
+	case strings.HasPrefix(region, "cn-"):
+		return partitions[2]
+	default:
+		return partitions[0]
+	}
+}
+
+// LookupPartition returns the partition named id.
+func LookupPartition(id string) (Partition, error) {
+	for _, p := range partitions {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return Partition{}, fmt.Errorf("partition not supported: %s", id)
+}
+
+// orDefault returns the aws partition for the zero value, so that an unset partition means the aws partition.
+func (p Partition) orDefault() Partition {
+	if p.ID == "" {
+		return partitions[0]
+	}
+	return p
+}
+
+// GlobalRegion returns the region to call service in, for services having a single endpoint in the partition.
+func (p Partition) GlobalRegion(service string) string {
+	p = p.orDefault()
+	if region, ok := p.GlobalRegions[service]; ok {
+		return region
+	}
+	return p.DefaultRegion
+}
+
+// ARN builds the ARN of resource in the partition.
+func (p Partition) ARN(service, region, accountID, resource string) string {
+	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", p.orDefault().ID, service, region, accountID, resource)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestPartitionOfRegion(t *testing.T) {
+	cases := []struct {
+		name     string
+		region   string
+		expected string
+	}{
+		{
+			name:     "region in aws partition",
+			region:   "ap-northeast-1",
+			expected: "aws",
+		},
+		{
+			name:     "region in aws-us-gov partition",
+			region:   "us-gov-west-1",
+			expected: "aws-us-gov",
+		},
+		{
+			name:     "region in aws-cn partition",
+			region:   "cn-north-1",
+			expected: "aws-cn",
+		},
+		{
+			name:     "empty region",
+			region:   "",
+			expected: "aws",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := PartitionOfRegion(tt.region)
+
+			if actual.ID != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual.ID)
+			}
+		})
+	}
+}
+
+func TestLookupPartition(t *testing.T) {
+	cases := []struct {
+		name      string
+		id        string
+		expected  string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "lookup aws partition",
+			id:       "aws",
+			expected: "us-east-1",
+		},
+		{
+			name:     "lookup aws-us-gov partition",
+			id:       "aws-us-gov",
+			expected: "us-gov-west-1",
+		},
+		{
+			name:     "lookup aws-cn partition",
+			id:       "aws-cn",
+			expected: "cn-north-1",
+		},
+		{
+			name:      "lookup undefined partition",
+			id:        "aws-iso",
+			wantErr:   true,
+			expectErr: "partition not supported: aws-iso",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := LookupPartition(tt.id)
+
+			if tt.wantErr {
+				if err == nil || err.Error() != tt.expectErr {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected nil, but got %v", err.Error())
+			}
+			if actual.DefaultRegion != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual.DefaultRegion)
+			}
+		})
+	}
+}
+
+func TestPartitionGlobalRegion(t *testing.T) {
+	cases := []struct {
+		name      string
+		partition Partition
+		service   string
+		expected  string
+	}{
+		{
+			name:      "iam in unset partition",
+			partition: Partition{},
+			service:   "iam",
+			expected:  "us-east-1",
+		},
+		{
+			name:      "iam in aws-us-gov partition",
+			partition: partitions[1],
+			service:   "iam",
+			expected:  "us-gov-west-1",
+		},
+		{
+			name:      "iam in aws-cn partition",
+			partition: partitions[2],
+			service:   "iam",
+			expected:  "cn-north-1",
+		},
+		{
+			name:      "route53 in aws-cn partition",
+			partition: partitions[2],
+			service:   "route53",
+			expected:  "cn-northwest-1",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.partition.GlobalRegion(tt.service)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPartitionARN(t *testing.T) {
+	cases := []struct {
+		name      string
+		partition Partition
+		expected  string
+	}{
+		{
+			name:      "arn in unset partition",
+			partition: Partition{},
+			expected:  "arn:aws:iam::123456789012:role/awsresq",
+		},
+		{
+			name:      "arn in aws-us-gov partition",
+			partition: partitions[1],
+			expected:  "arn:aws-us-gov:iam::123456789012:role/awsresq",
+		},
+		{
+			name:      "arn in aws-cn partition",
+			partition: partitions[2],
+			expected:  "arn:aws-cn:iam::123456789012:role/awsresq",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.partition.ARN("iam", "", "123456789012", "role/awsresq")
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// defaultQueryTimeout is used when QueryOption does not specify a timeout.
+const defaultQueryTimeout = 30 * time.Second
+
+type ResourceQueryAPI func(ctx context.Context, ch chan ResultList, region string)
+
+// QueryOption controls how a query is fanned out across regions.
+type QueryOption struct {
+	// Timeout bounds the whole query across all regions. Zero means defaultQueryTimeout.
+	Timeout time.Duration
+	// MaxConcurrency limits the number of regions queried at the same time. Zero means no limit.
+	MaxConcurrency int
+	// Limiter bounds the regions queried at the same time across the queries sharing it, on top of MaxConcurrency.
+	// Nil means each query is only bounded by MaxConcurrency.
+	Limiter *Limiter
+	// TagFilters selects the resources to return by their tags.
+	TagFilters TagFilters
+	// Filters selects the resources to return by their attributes. They must be supported by the resource queried.
+	Filters Filters
+	// Partition selects the endpoints of global services. The zero value means the aws partition.
+	Partition Partition
+	// Clients shares the API clients between the services. Nil means each service caches its own clients.
+	Clients *ClientCache
+	// Account identifies the account of the credentials when Clients is shared by several accounts.
+	Account string
+	// Envelope wraps each result in an Envelope. The tags of the results are fetched to fill the envelopes,
+	// which takes an API call per result for the resources whose list API does not return their tags.
+	Envelope bool
+	// OrderByID orders the results of each region by the IDs of their envelopes, which raw results cannot be
+	// sorted by once returned. Otherwise the results of each region are in the order the APIs return them.
+	OrderByID bool
+	// ARN selects the single resource identified by the ARN, which is looked up with a targeted API
+	// when the resource has one. Nil means every resource.
+	ARN *ResourceARN
+}
+
+// queryRegions runs apiQuery for every region on a bounded pool of workers
+// and merges the per-region results into resultList. The query stops when ctx is done or the timeout expires.
+func queryRegions(ctx context.Context, apiQuery ResourceQueryAPI, region []string, opt QueryOption, resultList *ResultList) error {
+	timeout := opt.Timeout
+	if timeout <= 0 {
+		timeout = defaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	workers := opt.MaxConcurrency
+	if workers <= 0 || workers > len(region) {
+		workers = len(region)
+	}
+
+	queue := make(chan int)
+	ch := make(chan regionResult)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for i := range queue {
+				// apiQuery reports once before returning, so that the report can be tagged with the region
+				if err := opt.Limiter.acquire(ctx); err != nil {
+					var resultList ResultList
+					resultList.addError(region[i], err)
+					ch <- regionResult{index: i, resultList: resultList}
+					continue
+				}
+				out := make(chan ResultList, 1)
+				apiQuery(ctx, out, region[i])
+				opt.Limiter.release()
+				ch <- regionResult{index: i, resultList: <-out}
+			}
+		}()
+	}
+	go func() {
+		for i := range region {
+			queue <- i
+		}
+		close(queue)
+	}()
+
+	return collectResults(ch, region, resultList)
+}
+
+// regionResult is the report of the region at index in the regions queried.
+type regionResult struct {
+	index      int
+	resultList ResultList
+}
+
+// collectResults waits until every region has reported on ch and merges the reports into resultList.
+// Reports are merged in the order of the regions rather than the order they arrive, so that the results are stable.
+// Regions that failed still contribute what they fetched before the failure.
+func collectResults(ch chan regionResult, region []string, resultList *ResultList) error {
+	reports := make([]ResultList, len(region))
+	for range region {
+		report := <-ch
+		reports[report.index] = report.resultList
+	}
+
+	failed := 0
+	for _, result := range reports {
+		resultList.Results = append(resultList.Results, result.Results...)
+		if len(result.Errors) > 0 {
+			resultList.Errors = append(resultList.Errors, result.Errors...)
+			failed++
+		}
+	}
+
+	if len(region) > 0 && failed == len(region) {
+		return ErrQueryFailed
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQueryRegions(t *testing.T) {
+	cases := []struct {
+		name           string
+		region         []string
+		opt            QueryOption
+		delay          time.Duration
+		expectedCount  int
+		expectedErrors int
+		expectErr      error
+	}{
+		{
+			name:          "query all regions with bounded concurrency",
+			region:        []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ap-northeast-1"},
+			opt:           QueryOption{MaxConcurrency: 2},
+			expectedCount: 5,
+		},
+		{
+			name:          "query all regions without concurrency limit",
+			region:        []string{"us-east-1", "us-east-2", "us-west-1"},
+			opt:           QueryOption{},
+			expectedCount: 3,
+		},
+		{
+			name:           "query exceeding timeout",
+			region:         []string{"us-east-1", "us-east-2"},
+			opt:            QueryOption{Timeout: 10 * time.Millisecond},
+			delay:          time.Second,
+			expectedCount:  0,
+			expectedErrors: 2,
+			expectErr:      ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning int32
+			apiQuery := func(ctx context.Context, ch chan ResultList, region string) {
+				resultList := ResultList{}
+
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				defer atomic.AddInt32(&running, -1)
+
+				select {
+				case <-time.After(tt.delay + time.Millisecond):
+					resultList.Results = append(resultList.Results, region)
+				case <-ctx.Done():
+					resultList.addError(region, ctx.Err())
+				}
+				ch <- resultList
+			}
+
+			resultList := &ResultList{}
+			err := queryRegions(context.Background(), apiQuery, tt.region, tt.opt, resultList)
+
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected %v, but got %v", tt.expectErr, err)
+			}
+			if len(resultList.Results) != tt.expectedCount {
+				t.Errorf("expected %v results, but got %v", tt.expectedCount, len(resultList.Results))
+			}
+			if len(resultList.Errors) != tt.expectedErrors {
+				t.Errorf("expected %v errors, but got %v", tt.expectedErrors, len(resultList.Errors))
+			}
+			if tt.opt.MaxConcurrency > 0 && int(maxRunning) > tt.opt.MaxConcurrency {
+				t.Errorf("expected at most %v concurrent queries, but got %v", tt.opt.MaxConcurrency, maxRunning)
+			}
+		})
+	}
+}
+
+func TestQueryRegionsOrder(t *testing.T) {
+	region := []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2"}
+	// the later the region, the earlier it answers
+	delays := map[string]time.Duration{
+		"us-east-1": 40 * time.Millisecond,
+		"us-east-2": 30 * time.Millisecond,
+		"us-west-1": 20 * time.Millisecond,
+		"us-west-2": 10 * time.Millisecond,
+	}
+	apiQuery := func(ctx context.Context, ch chan ResultList, region string) {
+		time.Sleep(delays[region])
+		ch <- ResultList{Results: []interface{}{region}}
+	}
+
+	resultList := &ResultList{}
+	if err := queryRegions(context.Background(), apiQuery, region, QueryOption{}, resultList); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, r := range region {
+		if resultList.Results[i] != r {
+			t.Errorf("expected results in the order of %v, but got %v", region, resultList.Results)
+			break
+		}
+	}
+}
+
+func TestQueryRegionsSharedLimiter(t *testing.T) {
+	region := []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2"}
+	var running, maxRunning int32
+	apiQuery := func(ctx context.Context, ch chan ResultList, region string) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		ch <- ResultList{Results: []interface{}{region}}
+	}
+
+	// three queries of four regions each, allowed four regions at a time on their own
+	opt := QueryOption{MaxConcurrency: 4, Limiter: NewLimiter(2)}
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resultList := &ResultList{}
+			if err := queryRegions(context.Background(), apiQuery, region, opt, resultList); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(resultList.Results) != len(region) {
+				t.Errorf("expected %d results, but got %d", len(region), len(resultList.Results))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 regions queried at the same time, but got %d", maxRunning)
+	}
+}
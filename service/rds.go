@@ -0,0 +1,319 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+type awsRdsAPI interface {
+	DescribeDBInstances(ctx context.Context, params *rds.DescribeDBInstancesInput, optFns ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error)
+	DescribeDBClusters(ctx context.Context, params *rds.DescribeDBClustersInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClustersOutput, error)
+	DescribeDBSnapshots(ctx context.Context, params *rds.DescribeDBSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBSnapshotsOutput, error)
+	DescribeDBClusterSnapshots(ctx context.Context, params *rds.DescribeDBClusterSnapshotsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBClusterSnapshotsOutput, error)
+	DescribeDBSubnetGroups(ctx context.Context, params *rds.DescribeDBSubnetGroupsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBSubnetGroupsOutput, error)
+	DescribeDBParameterGroups(ctx context.Context, params *rds.DescribeDBParameterGroupsInput, optFns ...func(*rds.Options)) (*rds.DescribeDBParameterGroupsOutput, error)
+	ListTagsForResource(ctx context.Context, params *rds.ListTagsForResourceInput, optFns ...func(*rds.Options)) (*rds.ListTagsForResourceOutput, error)
+}
+
+var rdsService = register(ServiceDefinition{
+	Name:        "rds",
+	Description: "Amazon Relational Database Service",
+	Resources: []ResourceDefinition{
+		newResource("db-instance", "RDS DB instances", []string{"DBInstanceIdentifier", "Engine", "DBInstanceClass", "DBInstanceStatus"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryDBInstance }).
+			withARN("db").
+			withFilters(multiValueFilters("db-cluster-id", "db-instance-id", "dbi-resource-id", "domain", "engine")...),
+		newResource("db-cluster", "RDS DB clusters", []string{"DBClusterIdentifier", "Engine", "EngineVersion", "Status"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryDBCluster }).
+			withARN("cluster").
+			withFilters(multiValueFilters("clone-group-id", "db-cluster-id", "db-cluster-resource-id", "domain", "engine")...),
+		newResource("db-snapshot", "RDS DB snapshots", []string{"DBSnapshotIdentifier", "DBInstanceIdentifier", "SnapshotType", "Status"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryDBSnapshot }).
+			withARN("snapshot").
+			withFilters(multiValueFilters("db-instance-id", "db-snapshot-id", "dbi-resource-id", "snapshot-type", "engine")...),
+		newResource("db-cluster-snapshot", "RDS DB cluster snapshots", []string{"DBClusterSnapshotIdentifier", "DBClusterIdentifier", "SnapshotType", "Status"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryDBClusterSnapshot }).
+			withARN("cluster-snapshot").
+			withFilters(multiValueFilters("db-cluster-id", "db-cluster-snapshot-id", "snapshot-type", "engine")...),
+		newResource("db-subnet-group", "RDS DB subnet groups", []string{"DBSubnetGroupName", "VpcId", "SubnetGroupStatus"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryDBSubnetGroup }).withARN("subgrp"),
+		newResource("parameter-group", "RDS DB parameter groups", []string{"DBParameterGroupName", "DBParameterGroupFamily", "Description"},
+			func(api *AwsresqRdsAPI) ResourceQueryAPI { return api.queryParameterGroup }).withARN("pg"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqRdsAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqRdsAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqRdsAPI(c aws.Config, region []string, opt QueryOption) *AwsresqRdsAPI {
+	return &AwsresqRdsAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqRdsAPI) Validate(resource string) bool {
+	return rdsService.Validate(resource)
+}
+
+func (api *AwsresqRdsAPI) DefaultColumns(resource string) []string {
+	return rdsService.DefaultColumns(resource)
+}
+
+func (api *AwsresqRdsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqRdsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return rdsService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqRdsAPI) client(region string) awsRdsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "rds", func() awsRdsAPI {
+		return rds.NewFromConfig(api.awsCfg, func(o *rds.Options) {
+			o.Region = region
+		})
+	})
+}
+
+func (api *AwsresqRdsAPI) queryDBInstance(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBInstancesOutput, types.DBInstance, types.DBInstance]{
+		service:  "rds",
+		resource: "db-instance",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBInstancesOutput, error) {
+			return client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+				Filters: rdsFilters(api.opt.Filters),
+				Marker:  token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBInstancesOutput, error) {
+			output, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+				DBInstanceIdentifier: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBInstancesOutput) ([]types.DBInstance, *string) {
+			return output.DBInstances, output.Marker
+		},
+		tags: func(_ context.Context, _ awsRdsAPI, instance types.DBInstance) (map[string]string, error) {
+			return rdsTagMap(instance.TagList), nil
+		},
+		envelope: func(instance types.DBInstance) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(instance.DBInstanceArn),
+				ID:        aws.ToString(instance.DBInstanceIdentifier),
+				Name:      aws.ToString(instance.DBInstanceIdentifier),
+				CreatedAt: instance.InstanceCreateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqRdsAPI) queryDBCluster(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBClustersOutput, types.DBCluster, types.DBCluster]{
+		service:  "rds",
+		resource: "db-cluster",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBClustersOutput, error) {
+			return client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{
+				Filters: rdsFilters(api.opt.Filters),
+				Marker:  token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBClustersOutput, error) {
+			output, err := client.DescribeDBClusters(ctx, &rds.DescribeDBClustersInput{
+				DBClusterIdentifier: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBClustersOutput) ([]types.DBCluster, *string) {
+			return output.DBClusters, output.Marker
+		},
+		tags: func(_ context.Context, _ awsRdsAPI, cluster types.DBCluster) (map[string]string, error) {
+			return rdsTagMap(cluster.TagList), nil
+		},
+		envelope: func(cluster types.DBCluster) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(cluster.DBClusterArn),
+				ID:        aws.ToString(cluster.DBClusterIdentifier),
+				Name:      aws.ToString(cluster.DBClusterIdentifier),
+				CreatedAt: cluster.ClusterCreateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqRdsAPI) queryDBSnapshot(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBSnapshotsOutput, types.DBSnapshot, types.DBSnapshot]{
+		service:  "rds",
+		resource: "db-snapshot",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBSnapshotsOutput, error) {
+			return client.DescribeDBSnapshots(ctx, &rds.DescribeDBSnapshotsInput{
+				Filters: rdsFilters(api.opt.Filters),
+				Marker:  token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBSnapshotsOutput, error) {
+			output, err := client.DescribeDBSnapshots(ctx, &rds.DescribeDBSnapshotsInput{
+				DBSnapshotIdentifier: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBSnapshotsOutput) ([]types.DBSnapshot, *string) {
+			return output.DBSnapshots, output.Marker
+		},
+		tags: func(_ context.Context, _ awsRdsAPI, snapshot types.DBSnapshot) (map[string]string, error) {
+			return rdsTagMap(snapshot.TagList), nil
+		},
+		envelope: func(snapshot types.DBSnapshot) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(snapshot.DBSnapshotArn),
+				ID:        aws.ToString(snapshot.DBSnapshotIdentifier),
+				Name:      aws.ToString(snapshot.DBSnapshotIdentifier),
+				CreatedAt: snapshot.SnapshotCreateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqRdsAPI) queryDBClusterSnapshot(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBClusterSnapshotsOutput, types.DBClusterSnapshot, types.DBClusterSnapshot]{
+		service:  "rds",
+		resource: "db-cluster-snapshot",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBClusterSnapshotsOutput, error) {
+			return client.DescribeDBClusterSnapshots(ctx, &rds.DescribeDBClusterSnapshotsInput{
+				Filters: rdsFilters(api.opt.Filters),
+				Marker:  token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBClusterSnapshotsOutput, error) {
+			output, err := client.DescribeDBClusterSnapshots(ctx, &rds.DescribeDBClusterSnapshotsInput{
+				DBClusterSnapshotIdentifier: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBClusterSnapshotsOutput) ([]types.DBClusterSnapshot, *string) {
+			return output.DBClusterSnapshots, output.Marker
+		},
+		tags: func(_ context.Context, _ awsRdsAPI, snapshot types.DBClusterSnapshot) (map[string]string, error) {
+			return rdsTagMap(snapshot.TagList), nil
+		},
+		envelope: func(snapshot types.DBClusterSnapshot) Envelope {
+			return Envelope{
+				ARN:       aws.ToString(snapshot.DBClusterSnapshotArn),
+				ID:        aws.ToString(snapshot.DBClusterSnapshotIdentifier),
+				Name:      aws.ToString(snapshot.DBClusterSnapshotIdentifier),
+				CreatedAt: snapshot.SnapshotCreateTime,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqRdsAPI) queryDBSubnetGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBSubnetGroupsOutput, types.DBSubnetGroup, types.DBSubnetGroup]{
+		service:  "rds",
+		resource: "db-subnet-group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBSubnetGroupsOutput, error) {
+			return client.DescribeDBSubnetGroups(ctx, &rds.DescribeDBSubnetGroupsInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBSubnetGroupsOutput, error) {
+			output, err := client.DescribeDBSubnetGroups(ctx, &rds.DescribeDBSubnetGroupsInput{
+				DBSubnetGroupName: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBSubnetGroupsOutput) ([]types.DBSubnetGroup, *string) {
+			return output.DBSubnetGroups, output.Marker
+		},
+		tags: func(ctx context.Context, client awsRdsAPI, group types.DBSubnetGroup) (map[string]string, error) {
+			return rdsListTags(ctx, client, group.DBSubnetGroupArn)
+		},
+		envelope: func(group types.DBSubnetGroup) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(group.DBSubnetGroupArn),
+				ID:   aws.ToString(group.DBSubnetGroupName),
+				Name: aws.ToString(group.DBSubnetGroupName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqRdsAPI) queryParameterGroup(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRdsAPI, *rds.DescribeDBParameterGroupsOutput, types.DBParameterGroup, types.DBParameterGroup]{
+		service:  "rds",
+		resource: "parameter-group",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRdsAPI, _ string, token *string) (*rds.DescribeDBParameterGroupsOutput, error) {
+			return client.DescribeDBParameterGroups(ctx, &rds.DescribeDBParameterGroupsInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsRdsAPI, target ResourceARN) (string, *rds.DescribeDBParameterGroupsOutput, error) {
+			output, err := client.DescribeDBParameterGroups(ctx, &rds.DescribeDBParameterGroupsInput{
+				DBParameterGroupName: aws.String(target.ID),
+			})
+			return "", output, err
+		},
+		items: func(output *rds.DescribeDBParameterGroupsOutput) ([]types.DBParameterGroup, *string) {
+			return output.DBParameterGroups, output.Marker
+		},
+		tags: func(ctx context.Context, client awsRdsAPI, group types.DBParameterGroup) (map[string]string, error) {
+			return rdsListTags(ctx, client, group.DBParameterGroupArn)
+		},
+		envelope: func(group types.DBParameterGroup) Envelope {
+			return Envelope{
+				ARN:  aws.ToString(group.DBParameterGroupArn),
+				ID:   aws.ToString(group.DBParameterGroupName),
+				Name: aws.ToString(group.DBParameterGroupName),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+// rdsListTags returns the tags of the resources whose describe API does not return them.
+func rdsListTags(ctx context.Context, client awsRdsAPI, arn *string) (map[string]string, error) {
+	output, err := client.ListTagsForResource(ctx, &rds.ListTagsForResourceInput{
+		ResourceName: arn,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rdsTagMap(output.TagList), nil
+}
+
+// rdsFilters translates filters into the filters of the describe APIs, which take the same names and values.
+func rdsFilters(filters Filters) []types.Filter {
+	var rdsFilters []types.Filter
+	for _, f := range filters {
+		rdsFilters = append(rdsFilters, types.Filter{
+			Name:   aws.String(f.Name),
+			Values: f.Values,
+		})
+	}
+	return rdsFilters
+}
+
+func rdsTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
+}
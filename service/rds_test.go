@@ -0,0 +1,558 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestRdsValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqRdsAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate db-instance resource",
+			api:      AwsresqRdsAPI{},
+			resource: "db-instance",
+			expected: true,
+		},
+		{
+			name:     "validate db-cluster resource",
+			api:      AwsresqRdsAPI{},
+			resource: "db-cluster",
+			expected: true,
+		},
+		{
+			name:     "validate db-snapshot resource",
+			api:      AwsresqRdsAPI{},
+			resource: "db-snapshot",
+			expected: true,
+		},
+		{
+			name:     "validate db-cluster-snapshot resource",
+			api:      AwsresqRdsAPI{},
+			resource: "db-cluster-snapshot",
+			expected: true,
+		},
+		{
+			name:     "validate db-subnet-group resource",
+			api:      AwsresqRdsAPI{},
+			resource: "db-subnet-group",
+			expected: true,
+		},
+		{
+			name:     "validate parameter-group resource",
+			api:      AwsresqRdsAPI{},
+			resource: "parameter-group",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqRdsAPI{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRdsDBInstanceQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRdsAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeDBInstances(gomock.Any(), &rds.DescribeDBInstancesInput{}).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:test-db"),
+					DBInstanceIdentifier: aws.String("test-db"),
+					Engine:               aws.String("postgres"),
+				},
+			},
+			Marker: aws.String("next-marker"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeDBInstances(gomock.Any(), &rds.DescribeDBInstancesInput{
+			Marker: aws.String("next-marker"),
+		}).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:sample-db"),
+					DBInstanceIdentifier: aws.String("sample-db"),
+					Engine:               aws.String("mysql"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeDBInstances(gomock.Any(), &rds.DescribeDBInstancesInput{
+			Filters: []types.Filter{
+				{Name: aws.String("engine"), Values: []string{"mysql"}},
+			},
+		}).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:sample-db"),
+					DBInstanceIdentifier: aws.String("sample-db"),
+					Engine:               aws.String("mysql"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name      string
+		filters   Filters
+		expected  []types.DBInstance
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name: "query db-instance resource",
+			expected: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:test-db"),
+					DBInstanceIdentifier: aws.String("test-db"),
+					Engine:               aws.String("postgres"),
+				},
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:sample-db"),
+					DBInstanceIdentifier: aws.String("sample-db"),
+					Engine:               aws.String("mysql"),
+				},
+			},
+		},
+		{
+			name:    "query db-instance resource with filters",
+			filters: Filters{{Name: "engine", Values: []string{"mysql"}}},
+			expected: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:sample-db"),
+					DBInstanceIdentifier: aws.String("sample-db"),
+					Engine:               aws.String("mysql"),
+				},
+			},
+		},
+		{
+			name:      "query db-instance resource with unsupported filter",
+			filters:   Filters{{Name: "status", Values: []string{"available"}}},
+			wantErr:   true,
+			expectErr: "filter 'status' not supported",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqRdsAPI(config, []string{"ap-northeast-1"}, QueryOption{Filters: tt.filters})
+			api.clients.put("", "ap-northeast-1", "rds", mc)
+
+			actual, err := api.Query("db-instance")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error '%s', but got no error", tt.expectErr)
+				} else if !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected error '%s', but got '%s'", tt.expectErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if actual.Service != "rds" {
+				t.Errorf("expected service 'rds', but got '%v'", actual.Service)
+			}
+			if actual.Resource != "db-instance" {
+				t.Errorf("expected resource 'db-instance', but got '%v'", actual.Resource)
+			}
+
+			if len(tt.expected) != len(actual.Results) {
+				t.Fatalf("expected %d results, but got %d", len(tt.expected), len(actual.Results))
+			}
+			for i := range tt.expected {
+				actualOutput, ok := actual.Results[i].(types.DBInstance)
+				if !ok {
+					t.Errorf("expected types.DBInstance, but got %T", actual.Results[i])
+				}
+				if !reflect.DeepEqual(actualOutput, tt.expected[i]) {
+					t.Errorf("expected %v, but got %v", tt.expected[i], actualOutput)
+				}
+			}
+		})
+	}
+}
+
+func TestRdsDBInstanceQueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRdsAPI(ctrl)
+	mcFailed := mock_service.NewMockawsRdsAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeDBInstances(gomock.Any(), &rds.DescribeDBInstancesInput{}).
+		Return(&rds.DescribeDBInstancesOutput{
+			DBInstances: []types.DBInstance{
+				{
+					DBInstanceArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:db:test-db"),
+					DBInstanceIdentifier: aws.String("test-db"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mcFailed.EXPECT().
+		DescribeDBInstances(gomock.Any(), &rds.DescribeDBInstancesInput{}).
+		Return(nil, &smithy.OperationError{
+			ServiceID:     "RDS",
+			OperationName: "DescribeDBInstances",
+			Err: &smithy.GenericAPIError{
+				Code:    "AccessDenied",
+				Message: "User is not authorized to perform: rds:DescribeDBInstances",
+			},
+		}).
+		AnyTimes()
+
+	cases := []struct {
+		name           string
+		region         []string
+		expectedCount  int
+		expectedErrors []QueryError
+		expectErr      error
+	}{
+		{
+			name:          "query db-instance resource with partial failure",
+			region:        []string{"ap-northeast-1", "us-east-1"},
+			expectedCount: 1,
+			expectedErrors: []QueryError{
+				{
+					Region:    "us-east-1",
+					Operation: "DescribeDBInstances",
+					Code:      "AccessDenied",
+					Message:   "User is not authorized to perform: rds:DescribeDBInstances",
+				},
+			},
+			expectErr: nil,
+		},
+		{
+			name:          "query db-instance resource with total failure",
+			region:        []string{"us-east-1"},
+			expectedCount: 0,
+			expectedErrors: []QueryError{
+				{
+					Region:    "us-east-1",
+					Operation: "DescribeDBInstances",
+					Code:      "AccessDenied",
+					Message:   "User is not authorized to perform: rds:DescribeDBInstances",
+				},
+			},
+			expectErr: ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqRdsAPI(config, tt.region, QueryOption{})
+			api.clients.put("", "ap-northeast-1", "rds", mc)
+			api.clients.put("", "us-east-1", "rds", mcFailed)
+
+			actual, err := api.Query("db-instance")
+
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("expected %v, but got %v", tt.expectErr, err)
+			}
+			if actual == nil {
+				t.Fatalf("expected result list, but got nil")
+			}
+			if len(actual.Results) != tt.expectedCount {
+				t.Errorf("expected %v, but got %v", tt.expectedCount, len(actual.Results))
+			}
+			if !reflect.DeepEqual(actual.Errors, tt.expectedErrors) {
+				t.Errorf("expected %+v, but got %+v", tt.expectedErrors, actual.Errors)
+			}
+		})
+	}
+}
+
+func TestRdsDBClusterQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRdsAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeDBClusters(gomock.Any(), &rds.DescribeDBClustersInput{}).
+		Return(&rds.DescribeDBClustersOutput{
+			DBClusters: []types.DBCluster{
+				{
+					DBClusterArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:cluster:test-cluster"),
+					DBClusterIdentifier: aws.String("test-cluster"),
+					TagList: []types.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+					},
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []interface{}
+	}{
+		{
+			name: "query db-cluster resource",
+			expected: []interface{}{
+				types.DBCluster{
+					DBClusterArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:cluster:test-cluster"),
+					DBClusterIdentifier: aws.String("test-cluster"),
+					TagList: []types.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+					},
+				},
+			},
+		},
+		{
+			name: "query db-cluster resource in envelopes",
+			opt:  QueryOption{Envelope: true},
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:rds:ap-northeast-1:012345678901:cluster:test-cluster",
+					ID:       "test-cluster",
+					Name:     "test-cluster",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "rds",
+					Resource: "db-cluster",
+					Tags:     map[string]string{"env": "prod"},
+					Raw: types.DBCluster{
+						DBClusterArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:cluster:test-cluster"),
+						DBClusterIdentifier: aws.String("test-cluster"),
+						TagList: []types.Tag{
+							{Key: aws.String("env"), Value: aws.String("prod")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqRdsAPI(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put("", "ap-northeast-1", "rds", mc)
+
+			actual, err := api.Query("db-cluster")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestRdsSnapshotQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRdsAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeDBSnapshots(gomock.Any(), &rds.DescribeDBSnapshotsInput{
+			Filters: []types.Filter{
+				{Name: aws.String("snapshot-type"), Values: []string{"manual"}},
+			},
+		}).
+		Return(&rds.DescribeDBSnapshotsOutput{
+			DBSnapshots: []types.DBSnapshot{
+				{
+					DBSnapshotArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:snapshot:test-snapshot"),
+					DBSnapshotIdentifier: aws.String("test-snapshot"),
+					SnapshotType:         aws.String("manual"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeDBClusterSnapshots(gomock.Any(), &rds.DescribeDBClusterSnapshotsInput{
+			Filters: []types.Filter{
+				{Name: aws.String("snapshot-type"), Values: []string{"manual"}},
+			},
+		}).
+		Return(&rds.DescribeDBClusterSnapshotsOutput{
+			DBClusterSnapshots: []types.DBClusterSnapshot{
+				{
+					DBClusterSnapshotArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:cluster-snapshot:test-cluster-snapshot"),
+					DBClusterSnapshotIdentifier: aws.String("test-cluster-snapshot"),
+					SnapshotType:                aws.String("manual"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		resource string
+		expected []interface{}
+	}{
+		{
+			name:     "query db-snapshot resource",
+			resource: "db-snapshot",
+			expected: []interface{}{
+				types.DBSnapshot{
+					DBSnapshotArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:snapshot:test-snapshot"),
+					DBSnapshotIdentifier: aws.String("test-snapshot"),
+					SnapshotType:         aws.String("manual"),
+				},
+			},
+		},
+		{
+			name:     "query db-cluster-snapshot resource",
+			resource: "db-cluster-snapshot",
+			expected: []interface{}{
+				types.DBClusterSnapshot{
+					DBClusterSnapshotArn:        aws.String("arn:aws:rds:ap-northeast-1:012345678901:cluster-snapshot:test-cluster-snapshot"),
+					DBClusterSnapshotIdentifier: aws.String("test-cluster-snapshot"),
+					SnapshotType:                aws.String("manual"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqRdsAPI(config, []string{"ap-northeast-1"}, QueryOption{
+				Filters: Filters{{Name: "snapshot-type", Values: []string{"manual"}}},
+			})
+			api.clients.put("", "ap-northeast-1", "rds", mc)
+
+			actual, err := api.Query(tt.resource)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestRdsGroupQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRdsAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeDBSubnetGroups(gomock.Any(), &rds.DescribeDBSubnetGroupsInput{}).
+		Return(&rds.DescribeDBSubnetGroupsOutput{
+			DBSubnetGroups: []types.DBSubnetGroup{
+				{
+					DBSubnetGroupArn:  aws.String("arn:aws:rds:ap-northeast-1:012345678901:subgrp:test-subnet-group"),
+					DBSubnetGroupName: aws.String("test-subnet-group"),
+					VpcId:             aws.String("vpc-01234567"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		DescribeDBParameterGroups(gomock.Any(), &rds.DescribeDBParameterGroupsInput{}).
+		Return(&rds.DescribeDBParameterGroupsOutput{
+			DBParameterGroups: []types.DBParameterGroup{
+				{
+					DBParameterGroupArn:    aws.String("arn:aws:rds:ap-northeast-1:012345678901:pg:test-parameter-group"),
+					DBParameterGroupName:   aws.String("test-parameter-group"),
+					DBParameterGroupFamily: aws.String("postgres15"),
+				},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTagsForResource(gomock.Any(), &rds.ListTagsForResourceInput{
+			ResourceName: aws.String("arn:aws:rds:ap-northeast-1:012345678901:subgrp:test-subnet-group"),
+		}).
+		Return(&rds.ListTagsForResourceOutput{
+			TagList: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("prod")},
+			},
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListTagsForResource(gomock.Any(), &rds.ListTagsForResourceInput{
+			ResourceName: aws.String("arn:aws:rds:ap-northeast-1:012345678901:pg:test-parameter-group"),
+		}).
+		Return(&rds.ListTagsForResourceOutput{
+			TagList: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("dev")},
+			},
+		}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name          string
+		resource      string
+		tagFilters    TagFilters
+		expectedCount int
+	}{
+		{
+			name:          "query db-subnet-group resource with tags",
+			resource:      "db-subnet-group",
+			tagFilters:    TagFilters{{Key: "env", Value: "prod", HasValue: true}},
+			expectedCount: 1,
+		},
+		{
+			name:          "query parameter-group resource with tags",
+			resource:      "parameter-group",
+			tagFilters:    TagFilters{{Key: "env", Value: "prod", HasValue: true}},
+			expectedCount: 0,
+		},
+		{
+			name:          "query parameter-group resource",
+			resource:      "parameter-group",
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqRdsAPI(config, []string{"ap-northeast-1"}, QueryOption{TagFilters: tt.tagFilters})
+			api.clients.put("", "ap-northeast-1", "rds", mc)
+
+			actual, err := api.Query(tt.resource)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(actual.Results) != tt.expectedCount {
+				t.Errorf("expected %d results, but got %d", tt.expectedCount, len(actual.Results))
+			}
+		})
+	}
+}
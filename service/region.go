@@ -0,0 +1,47 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type awsRegionAPI interface {
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+}
+
+// DescribeRegions returns the regions enabled in the account of cfg, sorted by name.
+func DescribeRegions(ctx context.Context, cfg aws.Config, partition Partition) ([]string, error) {
+	return describeRegions(ctx, ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+		if o.Region == "" {
+			o.Region = partition.GlobalRegion("ec2")
+		}
+	}))
+}
+
+func describeRegions(ctx context.Context, client awsRegionAPI) ([]string, error) {
+	output, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("opt-in-status"),
+				Values: []string{"opt-in-not-required", "opted-in"},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, aws.ToString(region.RegionName))
+	}
+	sort.Strings(regions)
+
+	return regions, nil
+}
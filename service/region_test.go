@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestDescribeRegions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsRegionAPI(ctrl)
+
+	mc.EXPECT().
+		DescribeRegions(gomock.Any(), &ec2.DescribeRegionsInput{
+			AllRegions: aws.Bool(true),
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("opt-in-status"),
+					Values: []string{"opt-in-not-required", "opted-in"},
+				},
+			},
+		}).
+		Return(&ec2.DescribeRegionsOutput{
+			Regions: []types.Region{
+				{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+				{RegionName: aws.String("il-central-1"), OptInStatus: aws.String("opted-in")},
+				{RegionName: aws.String("ap-northeast-1"), OptInStatus: aws.String("opt-in-not-required")},
+			},
+		}, nil)
+
+	actual, err := describeRegions(context.TODO(), mc)
+	if err != nil {
+		t.Errorf("expected nil, but got %v", err.Error())
+	}
+
+	expected := []string{"ap-northeast-1", "il-central-1", "us-east-1"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
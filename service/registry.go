@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ServiceDefinition describes a service searchable with awsresq.
+type ServiceDefinition struct {
+	Name string
+	// Aliases are alternative names accepted in place of Name.
+	Aliases     []string
+	Description string
+	// Global services have a single endpoint in the partition and are queried only in its global region.
+	Global bool
+	// ARNNamespace is the service part of the ARNs of the resources. Empty means Name.
+	ARNNamespace string
+	Resources    []ResourceDefinition
+	// New builds the API querying the service in the regions.
+	New func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI
+}
+
+// ResourceDefinition describes a resource of a service.
+type ResourceDefinition struct {
+	Name        string
+	Description string
+	// Columns are printed in tabular output when no columns are specified.
+	Columns []string
+	// arnTypes are the resource types in the ARNs of the resource, such as "instance" of EC2 instances.
+	// Types nested in others, such as "table/*/backup" of DynamoDB backups, are made of glob patterns of segments.
+	// Resources without ARN types cannot be got by ARN.
+	arnTypes []string
+	// filters are the filters supported by the list API of the resource.
+	filters []filterDefinition
+	// query returns the function querying the resource with the API built by ServiceDefinition.New.
+	query func(api interface{}) ResourceQueryAPI
+}
+
+// registry holds the registered services by name.
+var registry = map[string]*ServiceDefinition{}
+
+// register adds def to the registry and returns it for the API of the service to refer to.
+func register(def ServiceDefinition) *ServiceDefinition {
+	if _, ok := registry[def.Name]; ok {
+		panic(fmt.Sprintf("service registered twice: %s", def.Name))
+	}
+	registry[def.Name] = &def
+	return &def
+}
+
+// newResource defines a resource queried by query with the API of type T.
+func newResource[T any](name, description string, columns []string, query func(api T) ResourceQueryAPI) ResourceDefinition {
+	return ResourceDefinition{
+		Name:        name,
+		Description: description,
+		Columns:     columns,
+		query: func(api interface{}) ResourceQueryAPI {
+			return query(api.(T))
+		},
+	}
+}
+
+// withARN makes the resource resolvable from ARNs having one of the resource types.
+// An empty type matches ARNs whose resource part has no type, such as those of S3 buckets.
+func (r ResourceDefinition) withARN(types ...string) ResourceDefinition {
+	r.arnTypes = types
+	return r
+}
+
+// withFilters makes the resource accept the filters.
+func (r ResourceDefinition) withFilters(filters ...filterDefinition) ResourceDefinition {
+	r.filters = filters
+	return r
+}
+
+// LookupService returns the service registered with name or one of its aliases.
+func LookupService(name string) (*ServiceDefinition, error) {
+	if def, ok := registry[name]; ok {
+		return def, nil
+	}
+	for _, def := range registry {
+		for _, alias := range def.Aliases {
+			if alias == name {
+				return def, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("service not supported: %s", name)
+}
+
+// Services returns the registered services sorted by name.
+func Services() []*ServiceDefinition {
+	services := make([]*ServiceDefinition, 0, len(registry))
+	for _, def := range registry {
+		services = append(services, def)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services
+}
+
+// Resource returns the resource of the service named name.
+func (d *ServiceDefinition) Resource(name string) (ResourceDefinition, bool) {
+	for _, r := range d.Resources {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return ResourceDefinition{}, false
+}
+
+// namespace returns the service part of the ARNs of the resources of the service.
+func (d *ServiceDefinition) namespace() string {
+	if d.ARNNamespace != "" {
+		return d.ARNNamespace
+	}
+	return d.Name
+}
+
+// ResourceNames returns the names of the resources of the service.
+func (d *ServiceDefinition) ResourceNames() []string {
+	names := make([]string, 0, len(d.Resources))
+	for _, r := range d.Resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// Validate reports whether the service has the resource.
+func (d *ServiceDefinition) Validate(resource string) bool {
+	_, ok := d.Resource(resource)
+	return ok
+}
+
+// DefaultColumns returns the columns printed in tabular output for the resource.
+func (d *ServiceDefinition) DefaultColumns(resource string) []string {
+	r, _ := d.Resource(resource)
+	return r.Columns
+}
+
+// ValidateFilters checks if the resource supports every filter.
+func (d *ServiceDefinition) ValidateFilters(resource string, filters Filters) error {
+	r, ok := d.Resource(resource)
+	if !ok {
+		return fmt.Errorf("resource '%s' not supported in %s service", resource, d.Name)
+	}
+	return validateFilters(d.Name, r, filters)
+}
+
+// query queries the resource with api in every region, or only in the global region for global services.
+func (d *ServiceDefinition) query(ctx context.Context, api interface{}, resource string, region []string, opt QueryOption) (*ResultList, error) {
+	r, ok := d.Resource(resource)
+	if !ok {
+		return nil, fmt.Errorf("resource '%s' not supported in %s service", resource, d.Name)
+	}
+	if err := validateFilters(d.Name, r, opt.Filters); err != nil {
+		return nil, err
+	}
+	if d.Global {
+		region = []string{opt.Partition.GlobalRegion(d.Name)}
+	}
+
+	resultList := &ResultList{
+		Service:  d.Name,
+		Resource: resource,
+	}
+	return resultList, queryRegions(ctx, r.query(api), region, opt, resultList)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestLookupService(t *testing.T) {
+	cases := []struct {
+		name      string
+		service   string
+		expected  string
+		wantErr   bool
+		expectErr string
+	}{
+		{
+			name:     "lookup service by name",
+			service:  "ecs",
+			expected: "ecs",
+		},
+		{
+			name:     "lookup service by alias",
+			service:  "cloudwatchlogs",
+			expected: "logs",
+		},
+		{
+			name:      "lookup undefined service",
+			service:   "custom",
+			wantErr:   true,
+			expectErr: "service not supported: custom",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := LookupService(tt.service)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("expected %v, but got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected nil, but got %v", err.Error())
+			}
+			if actual.Name != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual.Name)
+			}
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+	services := Services()
+
+	if !sort.SliceIsSorted(services, func(i, j int) bool { return services[i].Name < services[j].Name }) {
+		t.Errorf("expected services sorted by name")
+	}
+	for _, def := range services {
+		if def.New == nil {
+			t.Errorf("expected constructor of %s, but got nil", def.Name)
+		}
+		if len(def.Resources) == 0 {
+			t.Errorf("expected resources of %s, but got none", def.Name)
+		}
+		api := def.New(aws.Config{}, []string{"us-east-1"}, QueryOption{})
+		for _, r := range def.Resources {
+			if !api.Validate(r.Name) {
+				t.Errorf("expected %s %s to be valid", def.Name, r.Name)
+			}
+			if len(api.DefaultColumns(r.Name)) == 0 {
+				t.Errorf("expected default columns of %s %s, but got none", def.Name, r.Name)
+			}
+		}
+	}
+}
@@ -1,12 +1,60 @@
 package service
 
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrQueryFailed is returned by Query when no region could be queried successfully.
+var ErrQueryFailed = errors.New("query failed in all regions")
+
 type ResultList struct {
 	Service  string        `json:"service"`
 	Resource string        `json:"resource"`
 	Results  []interface{} `json:"results"`
+	Errors   []QueryError  `json:"errors,omitempty"`
+}
+
+// QueryError describes an API call that failed while querying a region.
+type QueryError struct {
+	// Account is only set in multi-account mode.
+	Account   string `json:"account,omitempty"`
+	Region    string `json:"region"`
+	Operation string `json:"operation,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
 }
 
 type AwsresqAPI interface {
 	Validate(resource string) bool
+	DefaultColumns(resource string) []string
 	Query(resource string) (*ResultList, error)
+	// QueryContext is Query stopping when ctx is done.
+	QueryContext(ctx context.Context, resource string) (*ResultList, error)
+}
+
+func newQueryError(region string, err error) QueryError {
+	queryErr := QueryError{
+		Region:  region,
+		Message: err.Error(),
+	}
+
+	var oe *smithy.OperationError
+	if errors.As(err, &oe) {
+		queryErr.Operation = oe.Operation()
+	}
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		queryErr.Code = ae.ErrorCode()
+		queryErr.Message = ae.ErrorMessage()
+	}
+
+	return queryErr
+}
+
+// addError records err as a failure of the query in region.
+func (r *ResultList) addError(region string, err error) {
+	r.Errors = append(r.Errors, newQueryError(region, err))
 }
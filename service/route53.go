@@ -3,95 +3,117 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
 
 type awsRoute53API interface {
 	ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error)
+	ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error)
+	GetHostedZone(ctx context.Context, params *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)
 }
 
+var route53Service = register(ServiceDefinition{
+	Name:        "route53",
+	Description: "Amazon Route 53",
+	Global:      true,
+	Resources: []ResourceDefinition{
+		newResource("hosted-zone", "Route 53 hosted zones", []string{"Id", "Name", "ResourceRecordSetCount", "Config.PrivateZone"},
+			func(api *AwsresqRoute53API) ResourceQueryAPI { return api.queryRoute53HostedZone }).withARN("hostedzone"),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqRoute53API(cfg, region, opt)
+	},
+})
+
 type AwsresqRoute53API struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsRoute53API
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqRoute53API(c aws.Config, region []string) *AwsresqRoute53API {
+func NewAwsresqRoute53API(c aws.Config, region []string, opt QueryOption) *AwsresqRoute53API {
 	return &AwsresqRoute53API{
-		awsCfg:    c,
-		region:    region,
-		apiClient: make(map[string]awsRoute53API, len(region)),
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqRoute53API) Validate(resource string) bool {
-	validResoruces := []string{
-		"hosted-zone",
-	}
-
-	return slices.Contains(validResoruces, resource)
+func (api *AwsresqRoute53API) Validate(resource string) bool {
+	return route53Service.Validate(resource)
 }
 
-func (api AwsresqRoute53API) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "route53",
-		Resource: resource,
-	}
-
-	var apiQuery ResourceQueryAPI
-	switch resource {
-	case "hosted-zone":
-		apiQuery = api.queryRoute53HostedZone
-	default:
-		return nil, fmt.Errorf("resource %s is not supported in ec2 service", resource)
-	}
-
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	for _, region := range api.region {
-		go apiQuery(ctx, ch, region)
-	}
-
-	for range api.region {
-		select {
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqRoute53API) DefaultColumns(resource string) []string {
+	return route53Service.DefaultColumns(resource)
+}
 
-	return resultList, nil
+func (api *AwsresqRoute53API) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
 }
 
-func (api AwsresqRoute53API) queryRoute53HostedZone(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "route53",
-		Resource: "hosted-zone",
-	}
+func (api *AwsresqRoute53API) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return route53Service.query(ctx, api, resource, api.region, api.opt)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = route53.NewFromConfig(api.awsCfg, func(o *route53.Options) {
+func (api *AwsresqRoute53API) client(region string) awsRoute53API {
+	return cachedClient(api.clients, api.opt.Account, region, "route53", func() awsRoute53API {
+		return route53.NewFromConfig(api.awsCfg, func(o *route53.Options) {
 			o.Region = region
 		})
-	}
+	})
+}
 
-	listOutput, err := api.apiClient[region].ListHostedZones(ctx, nil)
-	if err != nil {
-		log.Error().Err(err).Msgf("error listing hosted zones in %s", region)
-		return
-	}
-	for _, hostedZone := range listOutput.HostedZones {
-		resultList.Results = append(resultList.Results, hostedZone)
-	}
+func (api *AwsresqRoute53API) queryRoute53HostedZone(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsRoute53API, *route53.ListHostedZonesOutput, types.HostedZone, types.HostedZone]{
+		service:  "route53",
+		resource: "hosted-zone",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsRoute53API, _ string, token *string) (*route53.ListHostedZonesOutput, error) {
+			return client.ListHostedZones(ctx, &route53.ListHostedZonesInput{Marker: token})
+		},
+		lookup: func(ctx context.Context, client awsRoute53API, target ResourceARN) (string, *route53.ListHostedZonesOutput, error) {
+			output, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{
+				Id: aws.String(target.ID),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &route53.ListHostedZonesOutput{HostedZones: []types.HostedZone{*output.HostedZone}}, nil
+		},
+		items: func(output *route53.ListHostedZonesOutput) ([]types.HostedZone, *string) {
+			return output.HostedZones, output.NextMarker
+		},
+		tags: func(ctx context.Context, client awsRoute53API, hostedZone types.HostedZone) (map[string]string, error) {
+			output, err := client.ListTagsForResource(ctx, &route53.ListTagsForResourceInput{
+				ResourceType: types.TagResourceTypeHostedzone,
+				ResourceId:   aws.String(strings.TrimPrefix(aws.ToString(hostedZone.Id), "/hostedzone/")),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if output.ResourceTagSet == nil {
+				return nil, nil
+			}
+			return route53TagMap(output.ResourceTagSet.Tags), nil
+		},
+		envelope: func(hostedZone types.HostedZone) Envelope {
+			id := strings.TrimPrefix(aws.ToString(hostedZone.Id), "/hostedzone/")
+			return Envelope{
+				ARN:  api.opt.Partition.ARN("route53", "", "", "hostedzone/"+id),
+				ID:   id,
+				Name: aws.ToString(hostedZone.Name),
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	ch <- resultList
+func route53TagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
 }
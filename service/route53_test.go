@@ -51,7 +51,7 @@ func TestRoute53HostedZoneQuery(t *testing.T) {
 	mc := mock_service.NewMockawsRoute53API(ctrl)
 
 	mc.EXPECT().
-		ListHostedZones(gomock.Any(), nil).
+		ListHostedZones(gomock.Any(), &route53.ListHostedZonesInput{}).
 		Return(&route53.ListHostedZonesOutput{
 			HostedZones: []types.HostedZone{
 				{
@@ -59,6 +59,20 @@ func TestRoute53HostedZoneQuery(t *testing.T) {
 					Name: aws.String("example.com."),
 				},
 			},
+			NextMarker: aws.String("next-token"),
+		}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		ListHostedZones(gomock.Any(), &route53.ListHostedZonesInput{
+			Marker: aws.String("next-token"),
+		}).
+		Return(&route53.ListHostedZonesOutput{
+			HostedZones: []types.HostedZone{
+				{
+					Id:   aws.String("/hostedzone/210987654321"),
+					Name: aws.String("example.org."),
+				},
+			},
 		}, nil).
 		AnyTimes()
 
@@ -75,6 +89,10 @@ func TestRoute53HostedZoneQuery(t *testing.T) {
 					Id:   aws.String("/hostedzone/123456789012"),
 					Name: aws.String("example.com."),
 				},
+				{
+					Id:   aws.String("/hostedzone/210987654321"),
+					Name: aws.String("example.org."),
+				},
 			},
 			wantErr: false,
 		},
@@ -83,8 +101,9 @@ func TestRoute53HostedZoneQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqRoute53API(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			api := NewAwsresqRoute53API(config, []string{"ap-northeast-1"}, QueryOption{})
+			// hosted zones are queried in the global region regardless of the given regions
+			api.clients.put("", "us-east-1", "route53", mc)
 
 			actual, err := api.Query("hosted-zone")
 
@@ -3,97 +3,165 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"errors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 type awsS3API interface {
 	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
 }
 
+var s3Service = register(ServiceDefinition{
+	Name:        "s3",
+	Description: "Amazon Simple Storage Service",
+	// ListBuckets returns the buckets of every region
+	Global: true,
+	Resources: []ResourceDefinition{
+		newResource("bucket", "S3 buckets", []string{"Name", "CreationDate"},
+			func(api *AwsresqS3API) ResourceQueryAPI { return api.queryBucket }).withARN(""),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqS3API(cfg, region, opt)
+	},
+})
+
 type AwsresqS3API struct {
-	awsCfg    aws.Config
-	region    []string
-	apiClient map[string]awsS3API
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
 }
 
-func NewAwsresqS3API(awsConfig aws.Config, region []string) *AwsresqS3API {
+func NewAwsresqS3API(awsConfig aws.Config, region []string, opt QueryOption) *AwsresqS3API {
 	return &AwsresqS3API{
-		awsCfg:    awsConfig,
-		region:    region,
-		apiClient: make(map[string]awsS3API, len(region)),
+		awsCfg:  awsConfig,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
 	}
 }
 
-func (api AwsresqS3API) Validate(resource string) bool {
-	validResource := []string{
-		"bucket",
-	}
-
-	return slices.Contains(validResource, resource)
+func (api *AwsresqS3API) Validate(resource string) bool {
+	return s3Service.Validate(resource)
 }
 
-func (api AwsresqS3API) Query(resource string) (*ResultList, error) {
-	resultList := &ResultList{
-		Service:  "s3",
-		Resource: resource,
-	}
-	var apiQuery ResourceQueryAPI
-
-	switch resource {
-	case "bucket":
-		apiQuery = api.queryBucket
-	default:
-		return nil, fmt.Errorf("resource %s not supported in s3 service", resource)
-	}
+func (api *AwsresqS3API) DefaultColumns(resource string) []string {
+	return s3Service.DefaultColumns(resource)
+}
 
-	ch := make(chan ResultList)
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+func (api *AwsresqS3API) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
 
-	for _, r := range api.region {
-		go apiQuery(ctx, ch, r)
-	}
+func (api *AwsresqS3API) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return s3Service.query(ctx, api, resource, api.region, api.opt)
+}
 
-	for range api.region {
-		select {
-		case result := <-ch:
-			resultList.Results = append(resultList.Results, result.Results...)
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		}
-	}
+func (api *AwsresqS3API) client(region string) awsS3API {
+	return cachedClient(api.clients, api.opt.Account, region, "s3", func() awsS3API {
+		return s3.NewFromConfig(api.awsCfg, func(o *s3.Options) {
+			o.Region = region
+		})
+	})
+}
 
-	return resultList, nil
+// s3Bucket is a bucket along with its region, which ListBuckets does not return.
+// location is the error of GetBucketLocation when the region could not be got.
+type s3Bucket struct {
+	types.Bucket
+	region   string
+	location error
 }
 
 func (api *AwsresqS3API) queryBucket(ctx context.Context, ch chan ResultList, region string) {
-	resultList := ResultList{
-		Service:  "s3",
-		Resource: "bucket",
-	}
+	resourceQuery[awsS3API, *s3.ListBucketsOutput, types.Bucket, s3Bucket]{
+		service:  "s3",
+		resource: "bucket",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsS3API, _ string, _ *string) (*s3.ListBucketsOutput, error) {
+			return client.ListBuckets(ctx, nil)
+		},
+		items: func(output *s3.ListBucketsOutput) ([]types.Bucket, *string) {
+			return output.Buckets, nil
+		},
+		// the regions of the buckets are only needed for their envelopes and tags
+		describe: func(ctx context.Context, client awsS3API, _ string, buckets []types.Bucket) ([]s3Bucket, error) {
+			results := make([]s3Bucket, 0, len(buckets))
+			for _, bucket := range buckets {
+				result := s3Bucket{Bucket: bucket}
+				if api.opt.Envelope || len(api.opt.TagFilters) > 0 {
+					result.region, result.location = bucketRegion(ctx, client, bucket.Name)
+				}
+				results = append(results, result)
+			}
+			return results, nil
+		},
+		tags: func(ctx context.Context, client awsS3API, bucket s3Bucket) (map[string]string, error) {
+			if bucket.location != nil {
+				return nil, bucket.location
+			}
+			return bucketTags(ctx, client, bucket.Name, bucket.region)
+		},
+		raw: func(bucket s3Bucket) interface{} {
+			return bucket.Bucket
+		},
+		envelope: func(bucket s3Bucket) Envelope {
+			return Envelope{
+				ARN:       api.opt.Partition.ARN("s3", "", "", aws.ToString(bucket.Name)),
+				ID:        aws.ToString(bucket.Name),
+				Name:      aws.ToString(bucket.Name),
+				Region:    bucket.region,
+				CreatedAt: bucket.CreationDate,
+			}
+		},
+	}.run(ctx, ch, region)
+}
 
-	if api.apiClient[region] == nil {
-		api.apiClient[region] = s3.NewFromConfig(api.awsCfg, func(o *s3.Options) {
-			o.Region = region
-		})
+// bucketRegion returns the region where the bucket resides.
+func bucketRegion(ctx context.Context, client awsS3API, bucket *string) (string, error) {
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: bucket,
+	})
+	if err != nil {
+		return "", err
 	}
+	return bucketLocationRegion(location.LocationConstraint), nil
+}
 
-	listOutput, err := api.apiClient[region].ListBuckets(ctx, nil)
+// bucketTags returns the tags of the bucket.
+// The tags are fetched from the region where the bucket resides, as S3 rejects requests sent to other regions.
+func bucketTags(ctx context.Context, client awsS3API, bucket *string, region string) (map[string]string, error) {
+	output, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: bucket,
+	}, func(o *s3.Options) {
+		o.Region = region
+	})
 	if err != nil {
-		log.Error().Err(err).Msgf("Failed to list bucket in %s", region)
-		return
-	}
-	if len(listOutput.Buckets) > 0 {
-		for _, b := range listOutput.Buckets {
-			resultList.Results = append(resultList.Results, b)
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchTagSet" {
+			return map[string]string{}, nil
 		}
+		return nil, err
 	}
 
-	ch <- resultList
+	return tagMap(output.TagSet, func(t types.Tag) (*string, *string) { return t.Key, t.Value }), nil
+}
+
+// bucketLocationRegion converts the location constraint of a bucket into its region name.
+func bucketLocationRegion(location types.BucketLocationConstraint) string {
+	switch location {
+	case "":
+		return "us-east-1"
+	case types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return string(location)
+	}
 }
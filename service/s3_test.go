@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	"github.com/thaim/awsresq/mock"
 )
@@ -59,7 +61,7 @@ func TestS3BucketQuery(t *testing.T) {
 				},
 			},
 		}, nil).
-		AnyTimes()
+		Times(1)
 
 	cases := []struct {
 		name      string
@@ -81,8 +83,9 @@ func TestS3BucketQuery(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config, _ := config.LoadDefaultConfig(context.TODO())
-			api := NewAwsresqS3API(config, []string{"ap-northeast-1"})
-			api.apiClient["ap-northeast-1"] = mc
+			// buckets are listed once in the global region, whichever regions are searched
+			api := NewAwsresqS3API(config, []string{"ap-northeast-1", "eu-west-1"}, QueryOption{})
+			api.clients.put("", "us-east-1", "s3", mc)
 
 			actual, err := api.Query("bucket")
 
@@ -121,3 +124,86 @@ func TestS3BucketQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestS3BucketQueryEnvelope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsS3API(ctrl)
+
+	tokyo := types.Bucket{Name: aws.String("tokyo-bucket")}
+	virginia := types.Bucket{Name: aws.String("virginia-bucket")}
+	denied := types.Bucket{Name: aws.String("denied-bucket")}
+
+	mc.EXPECT().
+		ListBuckets(gomock.Any(), nil).
+		Return(&s3.ListBucketsOutput{Buckets: []types.Bucket{denied, tokyo, virginia}}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetBucketLocation(gomock.Any(), &s3.GetBucketLocationInput{Bucket: tokyo.Name}).
+		Return(&s3.GetBucketLocationOutput{LocationConstraint: types.BucketLocationConstraintApNortheast1}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetBucketLocation(gomock.Any(), &s3.GetBucketLocationInput{Bucket: virginia.Name}).
+		Return(&s3.GetBucketLocationOutput{}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetBucketLocation(gomock.Any(), &s3.GetBucketLocationInput{Bucket: denied.Name}).
+		Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "Access Denied"}).
+		Times(1)
+	mc.EXPECT().
+		GetBucketTagging(gomock.Any(), &s3.GetBucketTaggingInput{Bucket: tokyo.Name}, gomock.Any()).
+		Return(&s3.GetBucketTaggingOutput{TagSet: []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetBucketTagging(gomock.Any(), &s3.GetBucketTaggingInput{Bucket: virginia.Name}, gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "NoSuchTagSet"}).
+		Times(1)
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqS3API(config, []string{"ap-northeast-1", "us-east-1"}, QueryOption{Envelope: true})
+	api.clients.put("", "us-east-1", "s3", mc)
+
+	actual, err := api.Query("bucket")
+	if err != nil && !errors.Is(err, ErrQueryFailed) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the bucket whose location is denied is still reported, in the region queried
+	expected := []interface{}{
+		Envelope{
+			ARN:      "arn:aws:s3:::denied-bucket",
+			ID:       "denied-bucket",
+			Name:     "denied-bucket",
+			Region:   "us-east-1",
+			Service:  "s3",
+			Resource: "bucket",
+			Tags:     map[string]string{},
+			Raw:      denied,
+		},
+		Envelope{
+			ARN:      "arn:aws:s3:::tokyo-bucket",
+			ID:       "tokyo-bucket",
+			Name:     "tokyo-bucket",
+			Region:   "ap-northeast-1",
+			Service:  "s3",
+			Resource: "bucket",
+			Tags:     map[string]string{"env": "prod"},
+			Raw:      tokyo,
+		},
+		Envelope{
+			ARN:      "arn:aws:s3:::virginia-bucket",
+			ID:       "virginia-bucket",
+			Name:     "virginia-bucket",
+			Region:   "us-east-1",
+			Service:  "s3",
+			Resource: "bucket",
+			Tags:     map[string]string{},
+			Raw:      virginia,
+		},
+	}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+	if len(actual.Errors) != 1 || actual.Errors[0].Code != "AccessDenied" {
+		t.Errorf("expected an AccessDenied error, but got %+v", actual.Errors)
+	}
+}
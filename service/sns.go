@@ -0,0 +1,189 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+type awsSnsAPI interface {
+	ListTopics(ctx context.Context, params *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error)
+	GetTopicAttributes(ctx context.Context, params *sns.GetTopicAttributesInput, optFns ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error)
+	ListSubscriptions(ctx context.Context, params *sns.ListSubscriptionsInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsOutput, error)
+	ListTagsForResource(ctx context.Context, params *sns.ListTagsForResourceInput, optFns ...func(*sns.Options)) (*sns.ListTagsForResourceOutput, error)
+}
+
+var snsService = register(ServiceDefinition{
+	Name:        "sns",
+	Description: "Amazon Simple Notification Service",
+	Resources: []ResourceDefinition{
+		newResource("topic", "SNS topics with their attributes", []string{"TopicArn", "Attributes.DisplayName", "Attributes.SubscriptionsConfirmed", "Attributes.KmsMasterKeyId"},
+			func(api *AwsresqSnsAPI) ResourceQueryAPI { return api.queryTopic }).withARN(""),
+		// the ARNs of subscriptions are the ARNs of their topics followed by an ID, so they are not resolved
+		newResource("subscription", "SNS subscriptions and the SQS queues they deliver to", []string{"SubscriptionArn", "TopicArn", "Protocol", "Endpoint"},
+			func(api *AwsresqSnsAPI) ResourceQueryAPI { return api.querySubscription }),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqSnsAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqSnsAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqSnsAPI(c aws.Config, region []string, opt QueryOption) *AwsresqSnsAPI {
+	return &AwsresqSnsAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqSnsAPI) Validate(resource string) bool {
+	return snsService.Validate(resource)
+}
+
+func (api *AwsresqSnsAPI) DefaultColumns(resource string) []string {
+	return snsService.DefaultColumns(resource)
+}
+
+func (api *AwsresqSnsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqSnsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return snsService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqSnsAPI) client(region string) awsSnsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "sns", func() awsSnsAPI {
+		return sns.NewFromConfig(api.awsCfg, func(o *sns.Options) {
+			o.Region = region
+		})
+	})
+}
+
+// snsTopic is a topic along with its attributes, as ListTopics only returns the ARNs of topics.
+type snsTopic struct {
+	TopicArn   *string
+	Attributes map[string]string
+}
+
+// snsSubscription is a subscription along with the ARN of the SQS queue it delivers to, if any.
+type snsSubscription struct {
+	types.Subscription
+	QueueArn *string
+}
+
+func (api *AwsresqSnsAPI) queryTopic(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsSnsAPI, *sns.ListTopicsOutput, types.Topic, snsTopic]{
+		service:  "sns",
+		resource: "topic",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsSnsAPI, _ string, token *string) (*sns.ListTopicsOutput, error) {
+			return client.ListTopics(ctx, &sns.ListTopicsInput{NextToken: token})
+		},
+		lookup: func(_ context.Context, _ awsSnsAPI, target ResourceARN) (string, *sns.ListTopicsOutput, error) {
+			return "", &sns.ListTopicsOutput{
+				Topics: []types.Topic{{TopicArn: aws.String(target.String())}},
+			}, nil
+		},
+		items: func(output *sns.ListTopicsOutput) ([]types.Topic, *string) {
+			return output.Topics, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsSnsAPI, _ string, topics []types.Topic) ([]snsTopic, error) {
+			output, err := client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+				TopicArn: topics[0].TopicArn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return []snsTopic{{
+				TopicArn:   topics[0].TopicArn,
+				Attributes: output.Attributes,
+			}}, nil
+		},
+		batchSize: 1,
+		tags: func(ctx context.Context, client awsSnsAPI, topic snsTopic) (map[string]string, error) {
+			output, err := client.ListTagsForResource(ctx, &sns.ListTagsForResourceInput{ResourceArn: topic.TopicArn})
+			if err != nil {
+				return nil, err
+			}
+			return snsTagMap(output.Tags), nil
+		},
+		envelope: func(topic snsTopic) Envelope {
+			name := snsTopicName(aws.ToString(topic.TopicArn))
+			return Envelope{
+				ARN:  aws.ToString(topic.TopicArn),
+				ID:   name,
+				Name: name,
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+func (api *AwsresqSnsAPI) querySubscription(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsSnsAPI, *sns.ListSubscriptionsOutput, types.Subscription, snsSubscription]{
+		service:  "sns",
+		resource: "subscription",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsSnsAPI, _ string, token *string) (*sns.ListSubscriptionsOutput, error) {
+			return client.ListSubscriptions(ctx, &sns.ListSubscriptionsInput{NextToken: token})
+		},
+		items: func(output *sns.ListSubscriptionsOutput) ([]types.Subscription, *string) {
+			return output.Subscriptions, output.NextToken
+		},
+		describe: func(_ context.Context, _ awsSnsAPI, _ string, subscriptions []types.Subscription) ([]snsSubscription, error) {
+			results := make([]snsSubscription, 0, len(subscriptions))
+			for _, subscription := range subscriptions {
+				result := snsSubscription{Subscription: subscription}
+				// the endpoint of an sqs subscription is the ARN of the queue
+				if aws.ToString(subscription.Protocol) == "sqs" {
+					result.QueueArn = subscription.Endpoint
+				}
+				results = append(results, result)
+			}
+			return results, nil
+		},
+		envelope: func(subscription snsSubscription) Envelope {
+			topicARN := aws.ToString(subscription.TopicArn)
+			protocol := aws.ToString(subscription.Protocol)
+			envelope := Envelope{
+				ARN:  aws.ToString(subscription.SubscriptionArn),
+				ID:   aws.ToString(subscription.SubscriptionArn),
+				Name: snsTopicName(topicARN) + "/" + protocol,
+			}
+			// unconfirmed subscriptions have "PendingConfirmation" instead of an ARN, and are told apart by their endpoints
+			if !arn.IsARN(envelope.ARN) {
+				envelope.ARN = ""
+				envelope.ID = topicARN + ":" + protocol + ":" + aws.ToString(subscription.Endpoint)
+				envelope.Account = accountOfARN(topicARN)
+			}
+			return envelope
+		},
+	}.run(ctx, ch, region)
+}
+
+// snsTopicName returns the name of a topic, which is the resource part of its ARN.
+func snsTopicName(s string) string {
+	a, err := arn.Parse(s)
+	if err != nil {
+		return s
+	}
+	return a.Resource
+}
+
+func snsTagMap(tags []types.Tag) map[string]string {
+	return tagMap(tags, func(t types.Tag) (*string, *string) { return t.Key, t.Value })
+}
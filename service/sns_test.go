@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestSnsValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqSnsAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate topic resource",
+			api:      AwsresqSnsAPI{},
+			resource: "topic",
+			expected: true,
+		},
+		{
+			name:     "validate subscription resource",
+			api:      AwsresqSnsAPI{},
+			resource: "subscription",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqSnsAPI{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSnsTopicQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsSnsAPI(ctrl)
+
+	topicArn := "arn:aws:sns:ap-northeast-1:012345678901:notifications"
+	attributes := map[string]string{
+		"TopicArn":               topicArn,
+		"DisplayName":            "Notifications",
+		"SubscriptionsConfirmed": "2",
+	}
+
+	mc.EXPECT().
+		ListTopics(gomock.Any(), &sns.ListTopicsInput{}).
+		Return(&sns.ListTopicsOutput{
+			Topics: []types.Topic{{TopicArn: aws.String(topicArn)}},
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetTopicAttributes(gomock.Any(), &sns.GetTopicAttributesInput{TopicArn: aws.String(topicArn)}).
+		Return(&sns.GetTopicAttributesOutput{Attributes: attributes}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListTagsForResource(gomock.Any(), &sns.ListTagsForResourceInput{ResourceArn: aws.String(topicArn)}).
+		Return(&sns.ListTagsForResourceOutput{
+			Tags: []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+		}, nil).
+		Times(1)
+
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqSnsAPI(config, []string{"ap-northeast-1"}, QueryOption{Envelope: true})
+	api.clients.put("", "ap-northeast-1", "sns", mc)
+
+	actual, err := api.Query("topic")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	expected := []interface{}{
+		Envelope{
+			ARN:      topicArn,
+			ID:       "notifications",
+			Name:     "notifications",
+			Region:   "ap-northeast-1",
+			Account:  "012345678901",
+			Service:  "sns",
+			Resource: "topic",
+			Tags:     map[string]string{"env": "prod"},
+			Raw: snsTopic{
+				TopicArn:   aws.String(topicArn),
+				Attributes: attributes,
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+}
+
+func TestSnsSubscriptionQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsSnsAPI(ctrl)
+
+	sqsSubscription := types.Subscription{
+		SubscriptionArn: aws.String("arn:aws:sns:ap-northeast-1:012345678901:notifications:0123abcd-4567-89ef-0123-456789abcdef"),
+		TopicArn:        aws.String("arn:aws:sns:ap-northeast-1:012345678901:notifications"),
+		Protocol:        aws.String("sqs"),
+		Endpoint:        aws.String("arn:aws:sqs:ap-northeast-1:012345678901:orders"),
+	}
+	emailSubscription := types.Subscription{
+		SubscriptionArn: aws.String("PendingConfirmation"),
+		TopicArn:        aws.String("arn:aws:sns:ap-northeast-1:012345678901:notifications"),
+		Protocol:        aws.String("email"),
+		Endpoint:        aws.String("ops@example.com"),
+	}
+	smsSubscription := types.Subscription{
+		SubscriptionArn: aws.String("PendingConfirmation"),
+		TopicArn:        aws.String("arn:aws:sns:ap-northeast-1:012345678901:notifications"),
+		Protocol:        aws.String("sms"),
+		Endpoint:        aws.String("+15555550100"),
+	}
+
+	mc.EXPECT().
+		ListSubscriptions(gomock.Any(), &sns.ListSubscriptionsInput{}).
+		Return(&sns.ListSubscriptionsOutput{
+			Subscriptions: []types.Subscription{sqsSubscription},
+			NextToken:     aws.String("next-token"),
+		}, nil).
+		Times(2)
+	mc.EXPECT().
+		ListSubscriptions(gomock.Any(), &sns.ListSubscriptionsInput{NextToken: aws.String("next-token")}).
+		Return(&sns.ListSubscriptionsOutput{
+			Subscriptions: []types.Subscription{emailSubscription, smsSubscription},
+		}, nil).
+		Times(2)
+
+	sqsResult := snsSubscription{
+		Subscription: sqsSubscription,
+		QueueArn:     aws.String("arn:aws:sqs:ap-northeast-1:012345678901:orders"),
+	}
+	emailResult := snsSubscription{Subscription: emailSubscription}
+	smsResult := snsSubscription{Subscription: smsSubscription}
+
+	cases := []struct {
+		name     string
+		opt      QueryOption
+		expected []interface{}
+	}{
+		{
+			name:     "query subscription resource of every page",
+			expected: []interface{}{sqsResult, emailResult, smsResult},
+		},
+		{
+			name: "query subscription resource with envelopes telling pending subscriptions apart",
+			opt:  QueryOption{Envelope: true},
+			expected: []interface{}{
+				Envelope{
+					ARN:      "arn:aws:sns:ap-northeast-1:012345678901:notifications:0123abcd-4567-89ef-0123-456789abcdef",
+					ID:       "arn:aws:sns:ap-northeast-1:012345678901:notifications:0123abcd-4567-89ef-0123-456789abcdef",
+					Name:     "notifications/sqs",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "sns",
+					Resource: "subscription",
+					Tags:     map[string]string{},
+					Raw:      sqsResult,
+				},
+				Envelope{
+					ID:       "arn:aws:sns:ap-northeast-1:012345678901:notifications:email:ops@example.com",
+					Name:     "notifications/email",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "sns",
+					Resource: "subscription",
+					Tags:     map[string]string{},
+					Raw:      emailResult,
+				},
+				Envelope{
+					ID:       "arn:aws:sns:ap-northeast-1:012345678901:notifications:sms:+15555550100",
+					Name:     "notifications/sms",
+					Region:   "ap-northeast-1",
+					Account:  "012345678901",
+					Service:  "sns",
+					Resource: "subscription",
+					Tags:     map[string]string{},
+					Raw:      smsResult,
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqSnsAPI(config, []string{"ap-northeast-1"}, tt.opt)
+			api.clients.put("", "ap-northeast-1", "sns", mc)
+
+			actual, err := api.Query("subscription")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
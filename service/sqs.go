@@ -0,0 +1,164 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE_mock -destination=../mock/$GOFILE
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+type awsSqsAPI interface {
+	ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error)
+	GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error)
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error)
+}
+
+var sqsService = register(ServiceDefinition{
+	Name:        "sqs",
+	Description: "Amazon Simple Queue Service",
+	Resources: []ResourceDefinition{
+		newResource("queue", "SQS queues with their attributes", []string{"QueueUrl", "Attributes.VisibilityTimeout", "Attributes.ApproximateNumberOfMessages", "Attributes.SqsManagedSseEnabled", "RedrivePolicy.deadLetterTargetArn"},
+			func(api *AwsresqSqsAPI) ResourceQueryAPI { return api.queryQueue }).
+			withARN("").
+			withFilters(singleValueFilters("queue-name-prefix")...),
+	},
+	New: func(cfg aws.Config, region []string, opt QueryOption) AwsresqAPI {
+		return NewAwsresqSqsAPI(cfg, region, opt)
+	},
+})
+
+type AwsresqSqsAPI struct {
+	awsCfg  aws.Config
+	region  []string
+	clients *ClientCache
+	opt     QueryOption
+}
+
+func NewAwsresqSqsAPI(c aws.Config, region []string, opt QueryOption) *AwsresqSqsAPI {
+	return &AwsresqSqsAPI{
+		awsCfg:  c,
+		region:  region,
+		clients: clientCache(opt),
+		opt:     opt,
+	}
+}
+
+func (api *AwsresqSqsAPI) Validate(resource string) bool {
+	return sqsService.Validate(resource)
+}
+
+func (api *AwsresqSqsAPI) DefaultColumns(resource string) []string {
+	return sqsService.DefaultColumns(resource)
+}
+
+func (api *AwsresqSqsAPI) Query(resource string) (*ResultList, error) {
+	return api.QueryContext(context.Background(), resource)
+}
+
+func (api *AwsresqSqsAPI) QueryContext(ctx context.Context, resource string) (*ResultList, error) {
+	return sqsService.query(ctx, api, resource, api.region, api.opt)
+}
+
+func (api *AwsresqSqsAPI) client(region string) awsSqsAPI {
+	return cachedClient(api.clients, api.opt.Account, region, "sqs", func() awsSqsAPI {
+		return sqs.NewFromConfig(api.awsCfg, func(o *sqs.Options) {
+			o.Region = region
+		})
+	})
+}
+
+// sqsQueue is a queue along with its attributes, such as the approximate number of messages and encryption.
+// The redrive policy attribute is a JSON document, which is decoded so that the dead-letter queue can be queried.
+type sqsQueue struct {
+	QueueUrl      *string
+	Attributes    map[string]string
+	RedrivePolicy map[string]interface{}
+}
+
+func (api *AwsresqSqsAPI) queryQueue(ctx context.Context, ch chan ResultList, region string) {
+	resourceQuery[awsSqsAPI, *sqs.ListQueuesOutput, string, sqsQueue]{
+		service:  "sqs",
+		resource: "queue",
+		opt:      api.opt,
+		client:   api.client,
+		list: func(ctx context.Context, client awsSqsAPI, _ string, token *string) (*sqs.ListQueuesOutput, error) {
+			// ListQueues returns a next token only when MaxResults is set, otherwise it stops at 1,000 queues
+			return client.ListQueues(ctx, &sqs.ListQueuesInput{
+				QueueNamePrefix: optionalString(api.opt.Filters.value("queue-name-prefix")),
+				MaxResults:      aws.Int32(1000),
+				NextToken:       token,
+			})
+		},
+		lookup: func(ctx context.Context, client awsSqsAPI, target ResourceARN) (string, *sqs.ListQueuesOutput, error) {
+			output, err := client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+				QueueName:              aws.String(target.ID),
+				QueueOwnerAWSAccountId: optionalString(target.AccountID),
+			})
+			if err != nil {
+				return "", nil, err
+			}
+			return "", &sqs.ListQueuesOutput{QueueUrls: []string{aws.ToString(output.QueueUrl)}}, nil
+		},
+		items: func(output *sqs.ListQueuesOutput) ([]string, *string) {
+			return output.QueueUrls, output.NextToken
+		},
+		describe: func(ctx context.Context, client awsSqsAPI, _ string, urls []string) ([]sqsQueue, error) {
+			output, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(urls[0]),
+				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+			})
+			if err != nil {
+				return nil, err
+			}
+			queue := sqsQueue{
+				QueueUrl:   aws.String(urls[0]),
+				Attributes: output.Attributes,
+			}
+			if policy, ok := output.Attributes["RedrivePolicy"]; ok {
+				if err := json.Unmarshal([]byte(policy), &queue.RedrivePolicy); err != nil {
+					return nil, err
+				}
+			}
+			return []sqsQueue{queue}, nil
+		},
+		batchSize: 1,
+		tags: func(ctx context.Context, client awsSqsAPI, queue sqsQueue) (map[string]string, error) {
+			output, err := client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{QueueUrl: queue.QueueUrl})
+			if err != nil {
+				return nil, err
+			}
+			return output.Tags, nil
+		},
+		envelope: func(queue sqsQueue) Envelope {
+			name := sqsQueueName(aws.ToString(queue.QueueUrl))
+			return Envelope{
+				ARN:       queue.Attributes["QueueArn"],
+				ID:        name,
+				Name:      name,
+				CreatedAt: sqsTimestamp(queue.Attributes["CreatedTimestamp"]),
+			}
+		},
+	}.run(ctx, ch, region)
+}
+
+// sqsQueueName returns the name of a queue, which is the last segment of its URL.
+func sqsQueueName(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// sqsTimestamp converts a timestamp attribute, in seconds since the epoch, into a time.
+func sqsTimestamp(sec string) *time.Time {
+	n, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return nil
+	}
+	t := time.Unix(n, 0).UTC()
+	return &t
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/golang/mock/gomock"
+	"github.com/thaim/awsresq/mock"
+)
+
+func TestSqsValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		api      AwsresqSqsAPI
+		resource string
+		expected bool
+	}{
+		{
+			name:     "validate queue resource",
+			api:      AwsresqSqsAPI{},
+			resource: "queue",
+			expected: true,
+		},
+		{
+			name:     "validate undefined resource",
+			api:      AwsresqSqsAPI{},
+			resource: "undefined",
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.api.Validate(tt.resource)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSqsQueueQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsSqsAPI(ctrl)
+
+	ordersURL := "https://sqs.ap-northeast-1.amazonaws.com/012345678901/orders"
+	ordersDLQURL := "https://sqs.ap-northeast-1.amazonaws.com/012345678901/orders-dlq"
+	ordersAttributes := map[string]string{
+		"QueueArn":                    "arn:aws:sqs:ap-northeast-1:012345678901:orders",
+		"VisibilityTimeout":           "60",
+		"ApproximateNumberOfMessages": "3",
+		"SqsManagedSseEnabled":        "true",
+		"CreatedTimestamp":            "1700000000",
+		"RedrivePolicy":               `{"deadLetterTargetArn":"arn:aws:sqs:ap-northeast-1:012345678901:orders-dlq","maxReceiveCount":5}`,
+	}
+	ordersDLQAttributes := map[string]string{
+		"QueueArn":                    "arn:aws:sqs:ap-northeast-1:012345678901:orders-dlq",
+		"VisibilityTimeout":           "30",
+		"ApproximateNumberOfMessages": "0",
+		"KmsMasterKeyId":              "alias/aws/sqs",
+	}
+
+	mc.EXPECT().
+		ListQueues(gomock.Any(), &sqs.ListQueuesInput{MaxResults: aws.Int32(1000)}).
+		Return(&sqs.ListQueuesOutput{
+			QueueUrls: []string{ordersURL},
+			NextToken: aws.String("next-token"),
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListQueues(gomock.Any(), &sqs.ListQueuesInput{
+			MaxResults: aws.Int32(1000),
+			NextToken:  aws.String("next-token"),
+		}).
+		Return(&sqs.ListQueuesOutput{
+			QueueUrls: []string{ordersDLQURL},
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListQueues(gomock.Any(), &sqs.ListQueuesInput{
+			QueueNamePrefix: aws.String("orders-"),
+			MaxResults:      aws.Int32(1000),
+		}).
+		Return(&sqs.ListQueuesOutput{
+			QueueUrls: []string{ordersDLQURL},
+		}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetQueueAttributes(gomock.Any(), &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(ordersURL),
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+		}).
+		Return(&sqs.GetQueueAttributesOutput{Attributes: ordersAttributes}, nil).
+		AnyTimes()
+	mc.EXPECT().
+		GetQueueAttributes(gomock.Any(), &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(ordersDLQURL),
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+		}).
+		Return(&sqs.GetQueueAttributesOutput{Attributes: ordersDLQAttributes}, nil).
+		AnyTimes()
+
+	cases := []struct {
+		name     string
+		filters  Filters
+		expected []interface{}
+	}{
+		{
+			name: "query queue resource of every page with redrive policy",
+			expected: []interface{}{
+				sqsQueue{
+					QueueUrl:   aws.String(ordersURL),
+					Attributes: ordersAttributes,
+					RedrivePolicy: map[string]interface{}{
+						"deadLetterTargetArn": "arn:aws:sqs:ap-northeast-1:012345678901:orders-dlq",
+						"maxReceiveCount":     float64(5),
+					},
+				},
+				sqsQueue{
+					QueueUrl:   aws.String(ordersDLQURL),
+					Attributes: ordersDLQAttributes,
+				},
+			},
+		},
+		{
+			name:    "query queue resource with name prefix",
+			filters: Filters{{Name: "queue-name-prefix", Values: []string{"orders-"}}},
+			expected: []interface{}{
+				sqsQueue{
+					QueueUrl:   aws.String(ordersDLQURL),
+					Attributes: ordersDLQAttributes,
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := config.LoadDefaultConfig(context.TODO())
+			api := NewAwsresqSqsAPI(config, []string{"ap-northeast-1"}, QueryOption{Filters: tt.filters})
+			api.clients.put("", "ap-northeast-1", "sqs", mc)
+
+			actual, err := api.Query("queue")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual.Results, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual.Results)
+			}
+		})
+	}
+}
+
+func TestSqsQueueQueryByARN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mc := mock_service.NewMockawsSqsAPI(ctrl)
+
+	queueURL := "https://sqs.ap-northeast-1.amazonaws.com/012345678901/orders"
+	attributes := map[string]string{
+		"QueueArn":         "arn:aws:sqs:ap-northeast-1:012345678901:orders",
+		"CreatedTimestamp": "1700000000",
+	}
+
+	mc.EXPECT().
+		GetQueueUrl(gomock.Any(), &sqs.GetQueueUrlInput{
+			QueueName:              aws.String("orders"),
+			QueueOwnerAWSAccountId: aws.String("012345678901"),
+		}).
+		Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(queueURL)}, nil).
+		Times(1)
+	mc.EXPECT().
+		GetQueueAttributes(gomock.Any(), &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(queueURL),
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+		}).
+		Return(&sqs.GetQueueAttributesOutput{Attributes: attributes}, nil).
+		Times(1)
+	mc.EXPECT().
+		ListQueueTags(gomock.Any(), &sqs.ListQueueTagsInput{QueueUrl: aws.String(queueURL)}).
+		Return(&sqs.ListQueueTagsOutput{Tags: map[string]string{"env": "prod"}}, nil).
+		Times(1)
+
+	resourceARN, err := ParseResourceARN("arn:aws:sqs:ap-northeast-1:012345678901:orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, _ := config.LoadDefaultConfig(context.TODO())
+	api := NewAwsresqSqsAPI(config, []string{"ap-northeast-1"}, QueryOption{ARN: &resourceARN, Envelope: true})
+	api.clients.put("", "ap-northeast-1", "sqs", mc)
+
+	actual, err := api.Query("queue")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	createdAt := time.Unix(1700000000, 0).UTC()
+	expected := []interface{}{
+		Envelope{
+			ARN:       "arn:aws:sqs:ap-northeast-1:012345678901:orders",
+			ID:        "orders",
+			Name:      "orders",
+			Region:    "ap-northeast-1",
+			Account:   "012345678901",
+			Service:   "sqs",
+			Resource:  "queue",
+			Tags:      map[string]string{"env": "prod"},
+			CreatedAt: &createdAt,
+			Raw: sqsQueue{
+				QueueUrl:   aws.String(queueURL),
+				Attributes: attributes,
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual.Results)
+	}
+}
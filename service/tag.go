@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// TagFilter selects resources by one of their tags.
+type TagFilter struct {
+	Key string
+	// Value is only compared when HasValue is set, otherwise any value of Key matches.
+	Value    string
+	HasValue bool
+	// Exclude selects the resources which do not match the filter.
+	Exclude bool
+}
+
+// TagFilters selects resources matching all of its filters.
+type TagFilters []TagFilter
+
+// ParseTagFilter parses a filter in the form of "key=value" or "key".
+func ParseTagFilter(expr string, exclude bool) (TagFilter, error) {
+	key, value, hasValue := strings.Cut(expr, "=")
+	if key == "" {
+		return TagFilter{}, fmt.Errorf("invalid tag filter: '%s'", expr)
+	}
+
+	return TagFilter{
+		Key:      key,
+		Value:    value,
+		HasValue: hasValue,
+		Exclude:  exclude,
+	}, nil
+}
+
+func (f TagFilter) match(tags map[string]string) bool {
+	value, ok := tags[f.Key]
+	matched := ok && (!f.HasValue || value == f.Value)
+
+	return matched != f.Exclude
+}
+
+// Match reports whether tags satisfies every filter.
+func (f TagFilters) Match(tags map[string]string) bool {
+	for _, filter := range f {
+		if !filter.match(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagMap converts the Key/Value tag list of an SDK type into a map.
+func tagMap[T any](tags []T, kv func(T) (*string, *string)) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value := kv(tag)
+		m[aws.ToString(key)] = aws.ToString(value)
+	}
+	return m
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		expr     string
+		exclude  bool
+		expected TagFilter
+		wantErr  bool
+	}{
+		{
+			name:     "parse key and value",
+			expr:     "env=prod",
+			expected: TagFilter{Key: "env", Value: "prod", HasValue: true},
+		},
+		{
+			name:     "parse key only",
+			expr:     "env",
+			expected: TagFilter{Key: "env"},
+		},
+		{
+			name:     "parse empty value",
+			expr:     "env=",
+			expected: TagFilter{Key: "env", Value: "", HasValue: true},
+		},
+		{
+			name:     "parse value containing separator",
+			expr:     "query=a=b",
+			expected: TagFilter{Key: "query", Value: "a=b", HasValue: true},
+		},
+		{
+			name:     "parse exclusion",
+			expr:     "env=dev",
+			exclude:  true,
+			expected: TagFilter{Key: "env", Value: "dev", HasValue: true, Exclude: true},
+		},
+		{
+			name:    "parse empty key",
+			expr:    "=prod",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseTagFilter(tt.expr, tt.exclude)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, but got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTagFiltersMatch(t *testing.T) {
+	tags := map[string]string{
+		"env":  "prod",
+		"team": "payments",
+	}
+
+	cases := []struct {
+		name     string
+		filters  TagFilters
+		expected bool
+	}{
+		{
+			name:     "match without filters",
+			filters:  nil,
+			expected: true,
+		},
+		{
+			name:     "match key and value",
+			filters:  TagFilters{{Key: "env", Value: "prod", HasValue: true}},
+			expected: true,
+		},
+		{
+			name:     "unmatch value",
+			filters:  TagFilters{{Key: "env", Value: "dev", HasValue: true}},
+			expected: false,
+		},
+		{
+			name:     "match key only",
+			filters:  TagFilters{{Key: "team"}},
+			expected: true,
+		},
+		{
+			name:     "unmatch key only",
+			filters:  TagFilters{{Key: "owner"}},
+			expected: false,
+		},
+		{
+			name:     "match exclusion of other value",
+			filters:  TagFilters{{Key: "env", Value: "dev", HasValue: true, Exclude: true}},
+			expected: true,
+		},
+		{
+			name:     "unmatch exclusion of key",
+			filters:  TagFilters{{Key: "env", Exclude: true}},
+			expected: false,
+		},
+		{
+			name: "unmatch when one of filters fails",
+			filters: TagFilters{
+				{Key: "env", Value: "prod", HasValue: true},
+				{Key: "team", Value: "payments", HasValue: true, Exclude: true},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.filters.Match(tags)
+
+			if actual != tt.expected {
+				t.Errorf("expected %v, but got %v", tt.expected, actual)
+			}
+		})
+	}
+}